@@ -114,11 +114,13 @@ func (_placer *placer) placeConcurrent(assignment *placement.Assignment, groups
 	if assignment.AssignedGroup != nil {
 		assignment.AssignedGroup.Entities.Remove(entity)
 		assignment.AssignedGroup.Update()
+		scopeSet.Update(assignment.AssignedGroup)
 	}
 	if bestGroup != nil {
 		assignment.AssignedGroup = bestGroup
 		bestGroup.Entities.Add(entity)
 		bestGroup.Update()
+		scopeSet.Update(bestGroup)
 		assignment.Failed = false
 	}
 }
@@ -23,7 +23,9 @@
 package algorithms
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,31 +33,33 @@ import (
 	"github.com/svenskmand/mimir-lib/examples"
 	"github.com/svenskmand/mimir-lib/generation"
 	"github.com/svenskmand/mimir-lib/generation/orderings"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/metrics"
 	source "github.com/svenskmand/mimir-lib/model/orderings"
 	"github.com/svenskmand/mimir-lib/model/placement"
+	"github.com/svenskmand/mimir-lib/model/requirements"
 )
 
 func setup(concurrency int) (placer Placer, relocator Relocator, groups []*placement.Group, store1dbs, store2dbs []*placement.Entity) {
 	random := generation.NewRandom(42)
-	entityBuilder, entityTemplates := examples.CreateSchemalessEntityBuilder()
+	entityBuilder := examples.CreateSchemalessEntityBuilder()
 	entityBuilder.Ordering(orderings.NewOrderingBuilder(orderings.Negate(orderings.Metric(source.GroupSource, metrics.DiskFree))))
 
-	entityTemplates.
+	store1Bindings := labels.NewBindings().
 		Bind(examples.Instance.Name(), "store1").
 		Bind(examples.Datacenter.Name(), "dc1")
 	store1dbs = examples.CreateSchemalessEntities(
-		random, entityBuilder, entityTemplates, 4, 4)
+		random, entityBuilder, store1Bindings, 4, 4)
 
-	entityTemplates.
+	store2Bindings := labels.NewBindings().
 		Bind(examples.Instance.Name(), "store2").
 		Bind(examples.Datacenter.Name(), "dc1")
-	store2dbs = examples.CreateSchemalessEntities(random, entityBuilder, entityTemplates, 4, 4)
+	store2dbs = examples.CreateSchemalessEntities(random, entityBuilder, store2Bindings, 4, 4)
 
-	groupBuilder, groupTemplates := examples.CreateHostGroupsBuilder()
-	groupTemplates.Bind(examples.Datacenter.Name(), "dc1")
+	groupBuilder := examples.CreateHostGroupsBuilder()
+	groupBindings := labels.NewBindings().Bind(examples.Datacenter.Name(), "dc1")
 	groups = examples.CreateHostGroups(
-		random, groupBuilder, groupTemplates, 4, 16)
+		random, groupBuilder, groupBindings, 4, 16)
 	placer = NewPlacer(concurrency, 1)
 	relocator = NewRelocator(concurrency, 1)
 
@@ -152,3 +156,42 @@ func TestPlacer_Place_updates_metrics_and_relations_of_assigned_groups(t *testin
 		assert.Equal(t, diskUsed+diskUsedBefore, diskUsedAfter)
 	}
 }
+
+func TestPlacer_Place_consecutive_anti_affinity_placements_on_the_same_rack(t *testing.T) {
+	rack := labels.NewLabel("rack", "*")
+	relation := labels.NewLabel("redis", "instance", "store1")
+	scopeSets := map[string]func(groups []*placement.Group) *placement.ScopeSet{
+		"lazy": placement.NewScopeSet,
+		"indexed": func(groups []*placement.Group) *placement.ScopeSet {
+			return placement.NewIndexedScopeSet(groups, time.Now(), rack)
+		},
+	}
+	for name, newScopeSet := range scopeSets {
+		for concurrency := 1; concurrency <= 2; concurrency++ {
+			var groups []*placement.Group
+			for _, names := range [][]string{{"host1", "a1"}, {"host2", "a1"}, {"host3", "a2"}, {"host4", "a2"}} {
+				group := placement.NewGroup(names[0])
+				group.Labels.Add(labels.NewLabel("host", names[0]))
+				group.Labels.Add(labels.NewLabel("rack", names[1]))
+				groups = append(groups, group)
+			}
+			var assignments []*placement.Assignment
+			for i := 0; i < 3; i++ {
+				entity := placement.NewEntity(fmt.Sprintf("entity%v", i))
+				entity.Relations.Add(relation)
+				entity.Ordering = placement.NameOrdering()
+				entity.Requirement = requirements.NewRelationRequirement(rack, relation, requirements.LessThanEqual, 0)
+				assignments = append(assignments, placement.NewAssignment(entity))
+			}
+
+			NewPlacer(concurrency, 1).Place(assignments, groups, newScopeSet(groups))
+
+			assert.False(t, assignments[0].Failed, name)
+			assert.Equal(t, groups[0], assignments[0].AssignedGroup, name)
+			assert.False(t, assignments[1].Failed, name)
+			assert.Equal(t, groups[2], assignments[1].AssignedGroup, name)
+			assert.True(t, assignments[2].Failed, name)
+			assert.Nil(t, assignments[2].AssignedGroup, name)
+		}
+	}
+}
@@ -115,11 +115,13 @@ func (_relocator *relocator) Relocate(relocationRanks []*placement.RelocationRan
 		// Remove the entity from the current group before comparing the current group with other groups
 		currentGroup.Entities.Remove(entity)
 		currentGroup.Update()
+		scopeSet.Update(currentGroup)
 
 		_relocator.relocateConcurrent(relocationRank, groups, scopeSet)
 
 		// Add the entity back to the current group after having updated its relocation rank
 		currentGroup.Entities.Add(entity)
 		currentGroup.Update()
+		scopeSet.Update(currentGroup)
 	}
 }
@@ -55,7 +55,8 @@ type Store interface {
 	// already exists it will just update it while retaining the groups reservations.
 	Update(now time.Time, groups ...*placement.Group)
 
-	// Prune will remove any expired reservations from all groups, it will also remove any expired groups.
+	// Prune will remove any expired reservations from all groups, it will also remove any expired groups and any
+	// expired labels and relations of the remaining groups and their entities.
 	Prune(now time.Time, groupMaxAge, reservationMaxAge time.Duration)
 }
 
@@ -256,6 +257,11 @@ func (store *store) Prune(now time.Time, groupMaxAge, reservationMaxAge time.Dur
 		for _, entity := range removedEntities {
 			group.Entities.Remove(entity)
 		}
+		group.Labels.Prune(now)
+		group.Relations.Prune(now)
+		for _, entity := range group.Entities {
+			entity.Relations.Prune(now)
+		}
 	}
 	for _, groupName := range removedGroups {
 		delete(store.groups, groupName)
@@ -156,6 +156,16 @@ func TestStore_Search_find_groups_with_sku_A(t *testing.T) {
 	}
 }
 
+func TestStore_Search_find_groups_with_sku_A_or_C(t *testing.T) {
+	store := setupStore()
+	pattern := labels.NewLabel("sku", "{A,C}")
+	groups := store.Search(pattern, Label)
+
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "group1", groups[0].Name)
+	assert.Equal(t, "group4", groups[1].Name)
+}
+
 func TestStore_Search_find_groups_instance_A(t *testing.T) {
 	store := setupStore()
 	pattern := labels.NewLabel("instance", "A")
@@ -319,3 +329,29 @@ func TestStore_Prune_keeps_non_expired_reservations(t *testing.T) {
 	store.Prune(time.Now(), time.Hour, time.Hour)
 	assert.Equal(t, 2, len(store.Reserved()))
 }
+
+func TestStore_Prune_removes_expired_labels_and_relations(t *testing.T) {
+	store := setupStore()
+	now := time.Now()
+	issue := labels.NewLabel("issue", "disk-failure")
+	group3 := store.Find("group3")
+	group3.Labels.Add(issue)
+	group3.Labels.SetExpiry(issue, now.Add(time.Minute))
+	group4 := store.Find("group4")
+	relation := labels.NewLabel("instance", "B")
+	for _, entity := range group4.Entities {
+		entity.Relations.SetExpiry(relation, now.Add(time.Minute))
+	}
+	group4.Relations.SetExpiry(relation, now.Add(time.Minute))
+
+	store.Prune(now, time.Hour, time.Hour)
+	assert.Equal(t, 1, len(store.Search(issue, Label)))
+	assert.Equal(t, 2, len(store.Search(relation, Relation)))
+
+	store.Prune(now.Add(time.Minute), time.Hour, time.Hour)
+	assert.Equal(t, 0, len(store.Search(issue, Label)))
+	assert.Equal(t, 1, len(store.Search(relation, Relation)))
+	for _, entity := range group4.Entities {
+		assert.False(t, entity.Relations.Contains(relation))
+	}
+}
@@ -38,21 +38,16 @@ import (
 )
 
 // CreateSchemalessEntityBuilder creates an entity builder for creating entities representing Schemaless databases.
-func CreateSchemalessEntityBuilder() (builder placement.EntityBuilder, variables labels.TemplateSet) {
-	builder = placement.NewEntityBuilder()
-	variables = labels.NewTemplateSet()
+func CreateSchemalessEntityBuilder() placement.EntityBuilder {
+	builder := placement.NewEntityBuilder()
 	nameTemplate := labels.NewTemplate(fmt.Sprintf("%v-us1-cluster%v-db%v",
 		Instance.Variable(), Cluster.Variable(), Database.Variable()))
-	variables.Add(nameTemplate)
 	scopeTemplate := labels.NewTemplate("host", "*")
 	datacenterTemplate := labels.NewTemplate(Datacenter.Name(), Datacenter.Variable())
-	variables.Add(datacenterTemplate)
 	instanceRelationTemplate := labels.NewTemplate("schemaless", "instance", Instance.Variable())
-	variables.Add(instanceRelationTemplate)
 	clusterRelationTemplate := labels.NewTemplate(
 		"schemaless", "cluster", fmt.Sprintf("percona-cluster-%v-us1-db%v",
 			Instance.Variable(), Cluster.Variable()))
-	variables.Add(clusterRelationTemplate)
 	issueLabelTemplate := labels.NewTemplate("issue", "*")
 	volumeLocalTemplate := labels.NewTemplate(VolumeType.Name(), "local")
 	volumeZFSTemplate := labels.NewTemplate(VolumeType.Name(), "zfs")
@@ -77,20 +72,21 @@ func CreateSchemalessEntityBuilder() (builder placement.EntityBuilder, variables
 		AddRelation(clusterRelationTemplate).
 		AddMetric(metrics.DiskUsed, diskDistribution).
 		AddMetric(metrics.MemoryUsed, memoryDistribution)
-	return
+	return builder
 }
 
 // CreateSchemalessEntities will create a list of entities that represents the databases for all the clusters of a
-// Schemaless instance.
-func CreateSchemalessEntities(random generation.Random, builder placement.EntityBuilder, templates labels.TemplateSet,
+// Schemaless instance, the bindings should bind the instance and datacenter variables.
+func CreateSchemalessEntities(random generation.Random, builder placement.EntityBuilder, bindings *labels.Bindings,
 	clusters, perCluster int) []*mPlacement.Entity {
 	var entities []*mPlacement.Entity
 	for cluster := 1; cluster <= clusters; cluster++ {
 		for database := 1; database <= perCluster; database++ {
-			templates.
+			databaseBindings := bindings.
 				Bind(Cluster.Name(), fmt.Sprintf("%v", cluster)).
 				Bind(Database.Name(), fmt.Sprintf("%v", database))
-			entities = append(entities, builder.Generate(random, time.Duration(cluster*perCluster+database)))
+			entities = append(entities,
+				builder.Generate(random, databaseBindings, time.Duration(cluster*perCluster+database)))
 		}
 	}
 	return entities
@@ -98,18 +94,13 @@ func CreateSchemalessEntities(random generation.Random, builder placement.Entity
 
 // CreateHostGroupsBuilder will create a builder to generate groups representing hosts that belong to a rack in a
 // datacenter.
-func CreateHostGroupsBuilder() (builder placement.GroupBuilder, templates labels.TemplateSet) {
-	builder = placement.NewGroupBuilder()
-	templates = labels.NewTemplateSet()
+func CreateHostGroupsBuilder() placement.GroupBuilder {
+	builder := placement.NewGroupBuilder()
 	nameFormat := fmt.Sprintf("schemadock%v-%v", Host.Variable(), Datacenter.Variable())
 	nameTemplate := labels.NewTemplate(nameFormat)
-	templates.Add(nameTemplate)
 	hostTemplate := labels.NewTemplate(Host.Name(), nameFormat)
-	templates.Add(hostTemplate)
 	rackTemplate := labels.NewTemplate(Rack.Name(), Rack.Variable())
-	templates.Add(rackTemplate)
 	datacenterTemplate := labels.NewTemplate(Datacenter.Name(), Datacenter.Variable())
-	templates.Add(datacenterTemplate)
 	volumeTemplate := labels.NewTemplate(VolumeType.Name(), "local")
 	memoryDistribution := generation.NewDiscrete(map[float64]float64{128 * metrics.GiB: 1, 256 * metrics.GiB: 5})
 	builder.Name(nameTemplate).
@@ -123,19 +114,20 @@ func CreateHostGroupsBuilder() (builder placement.GroupBuilder, templates labels
 		AddMetric(metrics.MemoryFree, memoryDistribution).
 		AddMetric(metrics.DiskUsed, generation.NewUniformDiscrete(0)).
 		AddMetric(metrics.MemoryUsed, generation.NewUniformDiscrete(0))
-	return
+	return builder
 }
 
 // CreateHostGroups will create a given number of groups representing hosts distributed over a given number of racks
-// and all belonging to the same datacenter.
-func CreateHostGroups(random generation.Random, builder placement.GroupBuilder, templates labels.TemplateSet, racks,
+// and all belonging to the same datacenter, the bindings should bind the datacenter variable.
+func CreateHostGroups(random generation.Random, builder placement.GroupBuilder, bindings *labels.Bindings, racks,
 	hosts int) []*mPlacement.Group {
 	var groups []*mPlacement.Group
+	datacenter, _ := bindings.Lookup(Datacenter.Name())
 	for i := 0; i < hosts; i++ {
-		datacenter := templates.Mappings()[Datacenter.Name()]
-		templates.Bind(Rack.Name(), fmt.Sprintf("%v-a%v", datacenter, i%racks))
-		templates.Bind(Host.Name(), fmt.Sprintf("%v", i))
-		group := builder.Generate(random, time.Duration(i))
+		hostBindings := bindings.
+			Bind(Rack.Name(), fmt.Sprintf("%v-a%v", datacenter, i%racks)).
+			Bind(Host.Name(), fmt.Sprintf("%v", i))
+		group := builder.Generate(random, hostBindings, time.Duration(i))
 		group.Metrics.Update()
 		groups = append(groups, group)
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/svenskmand/mimir-lib/algorithms"
 	"github.com/svenskmand/mimir-lib/generation"
 	"github.com/svenskmand/mimir-lib/generation/orderings"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/metrics"
 	mOrderings "github.com/svenskmand/mimir-lib/model/orderings"
 	"github.com/svenskmand/mimir-lib/model/placement"
@@ -36,23 +37,23 @@ import (
 
 func Test(t *testing.T) {
 	random := generation.NewRandom(42)
-	entityBuilder, entityTemplates := CreateSchemalessEntityBuilder()
+	entityBuilder := CreateSchemalessEntityBuilder()
 	entityBuilder.Ordering(orderings.NewOrderingBuilder(orderings.Negate(orderings.Metric(mOrderings.GroupSource, metrics.DiskFree))))
 
-	entityTemplates.
+	store1Bindings := labels.NewBindings().
 		Bind(Instance.Name(), "store1").
 		Bind(Datacenter.Name(), "dc1")
 	entities := CreateSchemalessEntities(
-		random, entityBuilder, entityTemplates, 4, 4)
+		random, entityBuilder, store1Bindings, 4, 4)
 
-	entityTemplates.
+	store2Bindings := labels.NewBindings().
 		Bind(Instance.Name(), "store2").
 		Bind(Datacenter.Name(), "dc1")
-	entities = append(entities, CreateSchemalessEntities(random, entityBuilder, entityTemplates, 4, 4)...)
+	entities = append(entities, CreateSchemalessEntities(random, entityBuilder, store2Bindings, 4, 4)...)
 
-	groupBuilder, groupTemplates := CreateHostGroupsBuilder()
-	groupTemplates.Bind(Datacenter.Name(), "dc1")
-	groups := CreateHostGroups(random, groupBuilder, groupTemplates, 4, 16)
+	groupBuilder := CreateHostGroupsBuilder()
+	groupBindings := labels.NewBindings().Bind(Datacenter.Name(), "dc1")
+	groups := CreateHostGroups(random, groupBuilder, groupBindings, 4, 16)
 	placer := algorithms.NewPlacer(1, 1)
 
 	var assignments []*placement.Assignment
@@ -28,16 +28,15 @@ which will then create an instance of the type from the specifications given to
 Building a group can look like this:
 	random := rand.New(rand.NewSource(42))
 	// Create a template for the group name
-	nameTemplate := labels.NewLabelTemplate("schemadock$id$-$dc$")
+	nameTemplate := labels.NewTemplate("schemadock$id$-$dc:dc1$")
 	// Create the builder
 	builder := NewGroupBuilder().
 		Name(nameTemplate)
-	// Bind values to the names in the template, this makes reusing the builder to generate groups with different
-	// names easy.
-	nameTemplate.
-		Bind("id", "42").
-		Bind("dc", "dc1")
+	// Bind values to the names in the template, the bindings are immutable so the same builder can be used to generate
+	// groups with different names from many goroutines at the same time.
+	bindings := labels.NewBindings().
+		Bind("id", "42")
 	// Generate a group with name "schemadock42-dc1"
-	group := builder.Generate(random, time.Now())
+	group := builder.Generate(random, bindings, time.Now())
 */
 package generation
@@ -58,6 +58,17 @@ func Label(scope, pattern labels.Template) OrderingBuilder {
 	}
 }
 
+// LabelValue creates a custom ordering builder which order groups based on the highest value of their labels
+// matching the given pattern.
+func LabelValue(scope, pattern labels.Template, position int, valueType labels.ValueType) OrderingBuilder {
+	return &labelValueBuilder{
+		scope:     scope,
+		pattern:   pattern,
+		position:  position,
+		valueType: valueType,
+	}
+}
+
 // Constant creates a custom ordering builder that returns a tuple score which will always return a tuple of length one
 // with the given constant.
 func Constant(constant float64) OrderingBuilder {
@@ -98,6 +109,55 @@ func Multiply(subBuilders ...OrderingBuilder) OrderingBuilder {
 	}
 }
 
+// Min creates a custom ordering builder that takes the tuples of the sub expressions and return a tuple which will
+// have the length of the smallest tuple returned from the sub expressions where each entry is the minimum of the
+// corresponding entry in the tuple from the sub expressions.
+func Min(subBuilders ...OrderingBuilder) OrderingBuilder {
+	return &minBuilder{
+		subBuilders: subBuilders,
+	}
+}
+
+// Max creates a custom ordering builder that takes the tuples of the sub expressions and return a tuple which will
+// have the length of the smallest tuple returned from the sub expressions where each entry is the maximum of the
+// corresponding entry in the tuple from the sub expressions.
+func Max(subBuilders ...OrderingBuilder) OrderingBuilder {
+	return &maxBuilder{
+		subBuilders: subBuilders,
+	}
+}
+
+// Log creates a custom ordering builder that takes the natural logarithm of the given tuple.
+func Log(subBuilder OrderingBuilder) OrderingBuilder {
+	return &logBuilder{
+		subBuilder: subBuilder,
+	}
+}
+
+// Pow creates a custom ordering builder that raises the given tuple to the given exponent.
+func Pow(subBuilder OrderingBuilder, exponent float64) OrderingBuilder {
+	return &powBuilder{
+		subBuilder: subBuilder,
+		exponent:   exponent,
+	}
+}
+
+// Abs creates a custom ordering builder that takes the absolute value of the given tuple.
+func Abs(subBuilder OrderingBuilder) OrderingBuilder {
+	return &absBuilder{
+		subBuilder: subBuilder,
+	}
+}
+
+// Clamp creates a custom ordering builder that limits the given tuple to the interval [min, max].
+func Clamp(subBuilder OrderingBuilder, min, max float64) OrderingBuilder {
+	return &clampBuilder{
+		subBuilder: subBuilder,
+		min:        min,
+		max:        max,
+	}
+}
+
 // Map creates a custom ordering builder that changes the tuple according to which bucket each entry of the tuple falls.
 func Map(mapping *orderings.Mapping, subBuilder OrderingBuilder) OrderingBuilder {
 	return &mapBuilder{
@@ -119,7 +179,8 @@ type metricBuilder struct {
 	metricType metrics.Type
 }
 
-func (builder *metricBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *metricBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	return orderings.Metric(builder.source, builder.metricType)
 }
 
@@ -128,12 +189,13 @@ type relationBuilder struct {
 	pattern labels.Template
 }
 
-func (builder *relationBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *relationBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	var scope *labels.Label
 	if builder.scope != nil {
-		scope = builder.scope.Instantiate()
+		scope = labels.Must(builder.scope.Instantiate(bindings))
 	}
-	return orderings.Relation(scope, builder.pattern.Instantiate())
+	return orderings.Relation(scope, labels.Must(builder.pattern.Instantiate(bindings)))
 }
 
 type labelBuilder struct {
@@ -141,19 +203,38 @@ type labelBuilder struct {
 	pattern labels.Template
 }
 
-func (builder *labelBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *labelBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	var scope *labels.Label
+	if builder.scope != nil {
+		scope = labels.Must(builder.scope.Instantiate(bindings))
+	}
+	return orderings.Label(scope, labels.Must(builder.pattern.Instantiate(bindings)))
+}
+
+type labelValueBuilder struct {
+	scope     labels.Template
+	pattern   labels.Template
+	position  int
+	valueType labels.ValueType
+}
+
+func (builder *labelValueBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	var scope *labels.Label
 	if builder.scope != nil {
-		scope = builder.scope.Instantiate()
+		scope = labels.Must(builder.scope.Instantiate(bindings))
 	}
-	return orderings.Label(scope, builder.pattern.Instantiate())
+	pattern := labels.Must(builder.pattern.Instantiate(bindings))
+	return orderings.LabelValue(scope, pattern, builder.position, builder.valueType)
 }
 
 type constantBuilder struct {
 	constant float64
 }
 
-func (builder *constantBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *constantBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	return orderings.Constant(builder.constant)
 }
 
@@ -161,26 +242,29 @@ type negateBuilder struct {
 	subBuilder OrderingBuilder
 }
 
-func (builder *negateBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
-	return orderings.Negate(builder.subBuilder.Generate(random, time))
+func (builder *negateBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Negate(builder.subBuilder.Generate(random, bindings, time))
 }
 
 type inverseBuilder struct {
 	subBuilder OrderingBuilder
 }
 
-func (builder *inverseBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
-	return orderings.Inverse(builder.subBuilder.Generate(random, time))
+func (builder *inverseBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Inverse(builder.subBuilder.Generate(random, bindings, time))
 }
 
 type summationBuilder struct {
 	subBuilders []OrderingBuilder
 }
 
-func (builder *summationBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *summationBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	subOrderings := make([]placement.Ordering, 0, len(builder.subBuilders))
 	for _, subExpression := range builder.subBuilders {
-		subOrderings = append(subOrderings, subExpression.Generate(random, time))
+		subOrderings = append(subOrderings, subExpression.Generate(random, bindings, time))
 	}
 	return orderings.Sum(subOrderings...)
 }
@@ -189,31 +273,99 @@ type multiplyBuilder struct {
 	subBuilders []OrderingBuilder
 }
 
-func (builder *multiplyBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *multiplyBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	subOrderings := make([]placement.Ordering, 0, len(builder.subBuilders))
 	for _, subExpression := range builder.subBuilders {
-		subOrderings = append(subOrderings, subExpression.Generate(random, time))
+		subOrderings = append(subOrderings, subExpression.Generate(random, bindings, time))
 	}
 	return orderings.Multiply(subOrderings...)
 }
 
+type minBuilder struct {
+	subBuilders []OrderingBuilder
+}
+
+func (builder *minBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	subOrderings := make([]placement.Ordering, 0, len(builder.subBuilders))
+	for _, subExpression := range builder.subBuilders {
+		subOrderings = append(subOrderings, subExpression.Generate(random, bindings, time))
+	}
+	return orderings.Min(subOrderings...)
+}
+
+type maxBuilder struct {
+	subBuilders []OrderingBuilder
+}
+
+func (builder *maxBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	subOrderings := make([]placement.Ordering, 0, len(builder.subBuilders))
+	for _, subExpression := range builder.subBuilders {
+		subOrderings = append(subOrderings, subExpression.Generate(random, bindings, time))
+	}
+	return orderings.Max(subOrderings...)
+}
+
+type logBuilder struct {
+	subBuilder OrderingBuilder
+}
+
+func (builder *logBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Log(builder.subBuilder.Generate(random, bindings, time))
+}
+
+type powBuilder struct {
+	subBuilder OrderingBuilder
+	exponent   float64
+}
+
+func (builder *powBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Pow(builder.subBuilder.Generate(random, bindings, time), builder.exponent)
+}
+
+type absBuilder struct {
+	subBuilder OrderingBuilder
+}
+
+func (builder *absBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Abs(builder.subBuilder.Generate(random, bindings, time))
+}
+
+type clampBuilder struct {
+	subBuilder OrderingBuilder
+	min        float64
+	max        float64
+}
+
+func (builder *clampBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Clamp(builder.subBuilder.Generate(random, bindings, time), builder.min, builder.max)
+}
+
 type mapBuilder struct {
 	mapping    *orderings.Mapping
 	subBuilder OrderingBuilder
 }
 
-func (builder *mapBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
-	return orderings.Map(builder.mapping, builder.subBuilder.Generate(random, time))
+func (builder *mapBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return orderings.Map(builder.mapping, builder.subBuilder.Generate(random, bindings, time))
 }
 
 type concatenateBuilder struct {
 	subBuilders []OrderingBuilder
 }
 
-func (builder *concatenateBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (builder *concatenateBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	subOrderings := make([]placement.Ordering, 0, len(builder.subBuilders))
 	for _, subExpression := range builder.subBuilders {
-		subOrderings = append(subOrderings, subExpression.Generate(random, time))
+		subOrderings = append(subOrderings, subExpression.Generate(random, bindings, time))
 	}
 	return orderings.Concatenate(subOrderings...)
 }
@@ -39,7 +39,7 @@ import (
 
 func TestMetricBuilder_Generate_EntitySource(t *testing.T) {
 	ordering := Metric(orderings.EntitySource, metrics.DiskUsed).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -53,7 +53,7 @@ func TestMetricBuilder_Generate_EntitySource(t *testing.T) {
 
 func TestMetricBuilder_Generate_GroupSource(t *testing.T) {
 	ordering := Metric(orderings.GroupSource, metrics.DiskUsed).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -68,7 +68,7 @@ func TestMetricBuilder_Generate_GroupSource(t *testing.T) {
 func TestRelationBuilder_Generate_with_nil_scope(t *testing.T) {
 	pattern := labels.NewTemplate("schemaless", "instance", "*")
 	ordering := Relation(nil, pattern).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -76,14 +76,14 @@ func TestRelationBuilder_Generate_with_nil_scope(t *testing.T) {
 
 	assert.Equal(t, 1, len(tuple1))
 	assert.Equal(t, 1, len(tuple2))
-	assert.Equal(t, float64(group1.Relations.Count(pattern.Instantiate())), tuple1[0])
-	assert.Equal(t, float64(group2.Relations.Count(pattern.Instantiate())), tuple2[0])
+	assert.Equal(t, float64(group1.Relations.Count(labels.Must(pattern.Instantiate(nil)))), tuple1[0])
+	assert.Equal(t, float64(group2.Relations.Count(labels.Must(pattern.Instantiate(nil)))), tuple2[0])
 }
 
 func TestRelationBuilder_Generate_with_datacenter_scope(t *testing.T) {
 	pattern := labels.NewTemplate("schemaless", "instance", "*")
 	ordering := Relation(labels.NewTemplate("datacenter", "*"), pattern).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -91,7 +91,8 @@ func TestRelationBuilder_Generate_with_datacenter_scope(t *testing.T) {
 
 	assert.Equal(t, 1, len(tuple1))
 	assert.Equal(t, 1, len(tuple2))
-	expected := group1.Relations.Count(pattern.Instantiate()) + group2.Relations.Count(pattern.Instantiate())
+	label := labels.Must(pattern.Instantiate(nil))
+	expected := group1.Relations.Count(label) + group2.Relations.Count(label)
 	assert.Equal(t, float64(expected), tuple1[0])
 	assert.Equal(t, float64(expected), tuple2[0])
 }
@@ -99,7 +100,7 @@ func TestRelationBuilder_Generate_with_datacenter_scope(t *testing.T) {
 func TestLabelBuilder_Generate_with_nil_scope(t *testing.T) {
 	pattern := labels.NewTemplate("rack", "*")
 	ordering := Label(nil, pattern).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -107,14 +108,14 @@ func TestLabelBuilder_Generate_with_nil_scope(t *testing.T) {
 
 	assert.Equal(t, 1, len(tuple1))
 	assert.Equal(t, 1, len(tuple2))
-	assert.Equal(t, float64(group1.Labels.Count(pattern.Instantiate())), tuple1[0])
-	assert.Equal(t, float64(group2.Labels.Count(pattern.Instantiate())), tuple2[0])
+	assert.Equal(t, float64(group1.Labels.Count(labels.Must(pattern.Instantiate(nil)))), tuple1[0])
+	assert.Equal(t, float64(group2.Labels.Count(labels.Must(pattern.Instantiate(nil)))), tuple2[0])
 }
 
 func TestLabelBuilder_Generate_with_datacenter_scope(t *testing.T) {
 	pattern := labels.NewTemplate("rack", "*")
 	ordering := Label(labels.NewTemplate("datacenter", "*"), pattern).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -122,14 +123,25 @@ func TestLabelBuilder_Generate_with_datacenter_scope(t *testing.T) {
 
 	assert.Equal(t, 1, len(tuple1))
 	assert.Equal(t, 1, len(tuple2))
-	expected := group1.Labels.Count(pattern.Instantiate()) + group2.Labels.Count(pattern.Instantiate())
+	label := labels.Must(pattern.Instantiate(nil))
+	expected := group1.Labels.Count(label) + group2.Labels.Count(label)
 	assert.Equal(t, float64(expected), tuple1[0])
 	assert.Equal(t, float64(expected), tuple2[0])
 }
 
+func TestLabelValueBuilder_Generate(t *testing.T) {
+	ordering := LabelValue(nil, labels.NewTemplate("generation", "*"), 1, labels.Number).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group := placement.NewGroup("group")
+	group.Labels.Add(labels.NewLabel("generation", "3"))
+	scopeSet := placement.NewScopeSet([]*placement.Group{group})
+
+	assert.Equal(t, []float64{3}, ordering.Tuple(group, scopeSet, nil))
+}
+
 func TestConstantBuilder_Generate(t *testing.T) {
 	ordering := Constant(42.0).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -143,7 +155,7 @@ func TestConstantBuilder_Generate(t *testing.T) {
 
 func TestNegateBuilder_Generate(t *testing.T) {
 	ordering := Negate(Constant(42.0)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -157,7 +169,7 @@ func TestNegateBuilder_Generate(t *testing.T) {
 
 func TestInverseBuilder_Generate(t *testing.T) {
 	ordering := Inverse(Constant(42.0)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -171,7 +183,7 @@ func TestInverseBuilder_Generate(t *testing.T) {
 
 func TestSummationBuilder_Generate(t *testing.T) {
 	ordering := Sum(Constant(1.0), Constant(1.0)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -185,7 +197,7 @@ func TestSummationBuilder_Generate(t *testing.T) {
 
 func TestMultiplyBuilder_Generate(t *testing.T) {
 	ordering := Multiply(Constant(2.0), Constant(3.0)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -197,6 +209,66 @@ func TestMultiplyBuilder_Generate(t *testing.T) {
 	assert.Equal(t, 6.0, tuple2[0])
 }
 
+func TestMinBuilder_Generate(t *testing.T) {
+	ordering := Min(Constant(2.0), Constant(3.0)).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{2.0}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{2.0}, ordering.Tuple(group2, scopeSet, entity))
+}
+
+func TestMaxBuilder_Generate(t *testing.T) {
+	ordering := Max(Constant(2.0), Constant(3.0)).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{3.0}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{3.0}, ordering.Tuple(group2, scopeSet, entity))
+}
+
+func TestLogBuilder_Generate(t *testing.T) {
+	ordering := Log(Constant(math.E)).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{1.0}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{1.0}, ordering.Tuple(group2, scopeSet, entity))
+}
+
+func TestPowBuilder_Generate(t *testing.T) {
+	ordering := Pow(Constant(3.0), 2).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{9.0}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{9.0}, ordering.Tuple(group2, scopeSet, entity))
+}
+
+func TestAbsBuilder_Generate(t *testing.T) {
+	ordering := Abs(Constant(-42.0)).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{42.0}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{42.0}, ordering.Tuple(group2, scopeSet, entity))
+}
+
+func TestClampBuilder_Generate(t *testing.T) {
+	ordering := Clamp(Metric(orderings.GroupSource, metrics.DiskFree), 0, 1.25*metrics.TiB).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
+	scopeSet := placement.NewScopeSet(groups)
+
+	assert.Equal(t, []float64{1 * metrics.TiB}, ordering.Tuple(group1, scopeSet, entity))
+	assert.Equal(t, []float64{1.25 * metrics.TiB}, ordering.Tuple(group2, scopeSet, entity))
+}
+
 func TestMapBuilder_Generate(t *testing.T) {
 	mapping, _ := orderings.NewMapping(
 		orderings.NewBucket(
@@ -211,7 +283,7 @@ func TestMapBuilder_Generate(t *testing.T) {
 		),
 	)
 	ordering := Map(mapping, Relation(nil, labels.NewTemplate("schemaless", "instance", "mezzanine"))).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -225,7 +297,7 @@ func TestMapBuilder_Generate(t *testing.T) {
 
 func TestConcatenateBuilder_Generate(t *testing.T) {
 	ordering := Concatenate(Constant(2.0), Constant(3.0)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 	tuple1 := ordering.Tuple(group1, scopeSet, entity)
@@ -27,13 +27,15 @@ import (
 
 	"github.com/svenskmand/mimir-lib/generation"
 	gPlacement "github.com/svenskmand/mimir-lib/generation/placement"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/placement"
 )
 
 // OrderingBuilder is used to generate new orderings for use in tests and benchmarks.
 type OrderingBuilder interface {
-	// Generate will generate a custom ordering that depends on the random source and the time.
-	Generate(random generation.Random, time time.Duration) placement.Ordering
+	// Generate will generate a custom ordering that depends on the random source, the time and the bindings
+	// of the variables of its label templates.
+	Generate(random generation.Random, bindings *labels.Bindings, time time.Duration) placement.Ordering
 }
 
 // NewOrderingBuilder creates a new builder for building orderings.
@@ -47,6 +49,7 @@ type orderingBuilder struct {
 	subBuilder OrderingBuilder
 }
 
-func (builder *orderingBuilder) Generate(random generation.Random, time time.Duration) placement.Ordering {
-	return builder.subBuilder.Generate(random, time)
+func (builder *orderingBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
+	return builder.subBuilder.Generate(random, bindings, time)
 }
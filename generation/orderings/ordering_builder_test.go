@@ -37,7 +37,7 @@ import (
 
 func TestOrderingBuilder_Generate(t *testing.T) {
 	ordering := NewOrderingBuilder(Metric(mOrderings.GroupSource, metrics.DiskFree)).
-		Generate(generation.NewRandom(42), time.Duration(0))
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1, group2, groups, entity := internal.SetupTwoGroupsAndEntity()
 	scopeSet := placement.NewScopeSet(groups)
 
@@ -42,21 +42,25 @@ type EntityBuilder interface {
 	// AddRelation will add a relation generated from the given label builder,
 	AddRelation(template labels.Template) EntityBuilder
 
+	// AddWeightedRelation will add a relation generated from the given label builder with the given weight.
+	AddWeightedRelation(template labels.Template, weight float64) EntityBuilder
+
 	// AddMetric will add the metric generated from the given distribution.
 	AddMetric(metricType metrics.Type, distribution generation.Distribution) EntityBuilder
 
 	// Requirement will set the requirement builder to the given builder.
 	Requirement(builder RequirementBuilder) EntityBuilder
 
-	// Generate will generate an entity that depends on the random source and the time.
-	Generate(random generation.Random, time time.Duration) *placement.Entity
+	// Generate will generate an entity that depends on the random source, the time and the bindings
+	// of the variables of its label templates.
+	Generate(random generation.Random, bindings *labels.Bindings, time time.Duration) *placement.Entity
 }
 
 // NewEntityBuilder will create a new entity builder for generating entities.
 func NewEntityBuilder() EntityBuilder {
 	return &entityBuilder{
 		name:        labels.NewTemplate(),
-		relations:   map[labels.Template]struct{}{},
+		relations:   map[labels.Template]float64{},
 		metrics:     map[metrics.Type]generation.Distribution{},
 		requirement: &emptyRequirement{},
 		ordering:    &nameOrdering{},
@@ -65,7 +69,7 @@ func NewEntityBuilder() EntityBuilder {
 
 type entityBuilder struct {
 	name        labels.Template
-	relations   map[labels.Template]struct{}
+	relations   map[labels.Template]float64
 	metrics     map[metrics.Type]generation.Distribution
 	requirement RequirementBuilder
 	ordering    OrderingBuilder
@@ -82,7 +86,11 @@ func (builder *entityBuilder) Ordering(subBuilder OrderingBuilder) EntityBuilder
 }
 
 func (builder *entityBuilder) AddRelation(template labels.Template) EntityBuilder {
-	builder.relations[template] = struct{}{}
+	return builder.AddWeightedRelation(template, 1)
+}
+
+func (builder *entityBuilder) AddWeightedRelation(template labels.Template, weight float64) EntityBuilder {
+	builder.relations[template] = weight
 	return builder
 }
 
@@ -96,12 +104,13 @@ func (builder *entityBuilder) Requirement(subBuilder RequirementBuilder) EntityB
 	return builder
 }
 
-func (builder *entityBuilder) Generate(random generation.Random, time time.Duration) *placement.Entity {
-	result := placement.NewEntity(builder.name.Instantiate().String())
-	result.Ordering = builder.ordering.Generate(random, time)
-	result.Requirement = builder.requirement.Generate(random, time)
-	for relation := range builder.relations {
-		result.Relations.Add(relation.Instantiate())
+func (builder *entityBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) *placement.Entity {
+	result := placement.NewEntity(labels.Must(builder.name.Instantiate(bindings)).String())
+	result.Ordering = builder.ordering.Generate(random, bindings, time)
+	result.Requirement = builder.requirement.Generate(random, bindings, time)
+	for relation, weight := range builder.relations {
+		result.Relations.AddWeighted(labels.Must(relation.Instantiate(bindings)), weight)
 	}
 	for metric, distribution := range builder.metrics {
 		result.Metrics.Add(metric, distribution.Value(random, time))
@@ -23,21 +23,25 @@
 package placement_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/svenskmand/mimir-lib/examples"
 	"github.com/svenskmand/mimir-lib/generation"
+	"github.com/svenskmand/mimir-lib/generation/placement"
+	"github.com/svenskmand/mimir-lib/model/labels"
 )
 
 func TestEntityBuilder_Generate(t *testing.T) {
 	random := generation.NewRandom(42)
-	entityBuilder, variables := examples.CreateSchemalessEntityBuilder()
+	entityBuilder := examples.CreateSchemalessEntityBuilder()
 
-	variables.
+	bindings := labels.NewBindings().
 		Bind(examples.Instance.Name(), "somestore").
 		Bind(examples.Datacenter.Name(), "dc1")
-	entities := examples.CreateSchemalessEntities(random, entityBuilder, variables, 4, 4)
+	entities := examples.CreateSchemalessEntities(random, entityBuilder, bindings, 4, 4)
 
 	for _, entity := range entities {
 		assert.Equal(t, 2, entity.Relations.Size(), "incorrect relations count")
@@ -45,3 +49,37 @@ func TestEntityBuilder_Generate(t *testing.T) {
 		assert.NotNil(t, entity.Requirement)
 	}
 }
+
+func TestEntityBuilder_Generate_concurrently_with_different_bindings(t *testing.T) {
+	entityBuilder := examples.CreateSchemalessEntityBuilder()
+	bindings := labels.NewBindings().
+		Bind(examples.Datacenter.Name(), "dc1").
+		Bind(examples.Cluster.Name(), "1").
+		Bind(examples.Database.Name(), "1")
+
+	names := make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			instanceBindings := bindings.Bind(examples.Instance.Name(), fmt.Sprintf("store%v", i))
+			entity := entityBuilder.Generate(generation.NewRandom(int64(i)), instanceBindings, time.Duration(i))
+			names <- entity.Name
+		}(i)
+	}
+
+	found := map[string]bool{}
+	for i := 0; i < 8; i++ {
+		found[<-names] = true
+	}
+	assert.Equal(t, 8, len(found))
+	assert.True(t, found["store3-us1-cluster1-db1"])
+}
+
+func TestEntityBuilder_AddWeightedRelation(t *testing.T) {
+	relation := labels.NewTemplate("redis", "instance", "store1")
+	entity := placement.NewEntityBuilder().
+		Name(labels.NewTemplate("entity")).
+		AddWeightedRelation(relation, 0.5).
+		Generate(generation.NewRandom(42), nil, time.Duration(0))
+
+	assert.Equal(t, 0.5, entity.Relations.Weight(labels.Must(relation.Instantiate(nil))))
+}
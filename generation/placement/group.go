@@ -42,8 +42,9 @@ type GroupBuilder interface {
 	// AddLabel will add a label generated by the label template.
 	AddLabel(template labels.Template) GroupBuilder
 
-	// Generate will generate a group that depends on the random source and the time.
-	Generate(random generation.Random, time time.Duration) *placement.Group
+	// Generate will generate a group that depends on the random source, the time and the bindings
+	// of the variables of its label templates.
+	Generate(random generation.Random, bindings *labels.Bindings, time time.Duration) *placement.Group
 }
 
 // NewGroupBuilder will create a new group builder for generating groups.
@@ -76,13 +77,14 @@ func (builder *groupBuilder) AddLabel(template labels.Template) GroupBuilder {
 	return builder
 }
 
-func (builder *groupBuilder) Generate(random generation.Random, time time.Duration) *placement.Group {
-	result := placement.NewGroup(builder.name.Instantiate().String())
+func (builder *groupBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) *placement.Group {
+	result := placement.NewGroup(labels.Must(builder.name.Instantiate(bindings)).String())
 	for metricType, distribution := range builder.metrics {
 		result.Metrics.Set(metricType, distribution.Value(random, time))
 	}
 	for factory := range builder.labels {
-		result.Labels.Add(factory.Instantiate())
+		result.Labels.Add(labels.Must(factory.Instantiate(bindings)))
 	}
 	return result
 }
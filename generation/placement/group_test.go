@@ -33,9 +33,9 @@ import (
 
 func TestGroupBuilder_Generate(t *testing.T) {
 	random := generation.NewRandom(42)
-	builder, templates := examples.CreateHostGroupsBuilder()
-	templates.Bind(examples.Datacenter.Name(), "dc1")
-	groups := examples.CreateHostGroups(random, builder, templates, 2, 8)
+	builder := examples.CreateHostGroupsBuilder()
+	bindings := labels.NewBindings().Bind(examples.Datacenter.Name(), "dc1")
+	groups := examples.CreateHostGroups(random, builder, bindings, 2, 8)
 
 	assert.Equal(t, 8, len(groups))
 	rackCounts := map[string]int{}
@@ -26,18 +26,21 @@ import (
 	"time"
 
 	"github.com/svenskmand/mimir-lib/generation"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/placement"
 )
 
 // OrderingBuilder is used to generate new ordering for use in tests and benchmarks.
 type OrderingBuilder interface {
-	// Generate will generate an ordering that depends on the random source and the time.
-	Generate(random generation.Random, time time.Duration) placement.Ordering
+	// Generate will generate an ordering that depends on the random source, the time and the bindings
+	// of the variables of its label templates.
+	Generate(random generation.Random, bindings *labels.Bindings, time time.Duration) placement.Ordering
 }
 
 type nameOrdering struct{}
 
-func (ordering *nameOrdering) Generate(random generation.Random, time time.Duration) placement.Ordering {
+func (ordering *nameOrdering) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Ordering {
 	return ordering
 }
 
@@ -33,12 +33,12 @@ import (
 
 func TestNameOrdering_Generate(t *testing.T) {
 	builder := &nameOrdering{}
-	ordering := builder.Generate(generation.NewRandom(42), time.Duration(0))
+	ordering := builder.Generate(generation.NewRandom(42), nil, time.Duration(0))
 	assert.Equal(t, builder, ordering)
 }
 
 func TestNameOrdering_Less(t *testing.T) {
-	ordering := (&nameOrdering{}).Generate(generation.NewRandom(42), time.Duration(0))
+	ordering := (&nameOrdering{}).Generate(generation.NewRandom(42), nil, time.Duration(0))
 	group1 := &placement.Group{Name: "a"}
 	group2 := &placement.Group{Name: "b"}
 
@@ -26,18 +26,21 @@ import (
 	"time"
 
 	"github.com/svenskmand/mimir-lib/generation"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/placement"
 )
 
 // RequirementBuilder is used to generate new requirements for use in tests and benchmarks.
 type RequirementBuilder interface {
-	// Generate will generate a requirement and a metric set that depends on the random source and the time.
-	Generate(random generation.Random, time time.Duration) placement.Requirement
+	// Generate will generate a requirement and a metric set that depends on the random source, the time and the bindings
+	// of the variables of its label templates.
+	Generate(random generation.Random, bindings *labels.Bindings, time time.Duration) placement.Requirement
 }
 
 type emptyRequirement struct{}
 
-func (requirement *emptyRequirement) Generate(random generation.Random, time time.Duration) placement.Requirement {
+func (requirement *emptyRequirement) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) placement.Requirement {
 	return requirement
 }
 
@@ -32,23 +32,23 @@ import (
 
 func TestEmptyRequirement_Generate(t *testing.T) {
 	builder := &emptyRequirement{}
-	requirement := builder.Generate(generation.NewRandom(42), time.Duration(0))
+	requirement := builder.Generate(generation.NewRandom(42), nil, time.Duration(0))
 
 	assert.Equal(t, builder, requirement)
 }
 
 func TestEmptyRequirement_Passed(t *testing.T) {
-	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), time.Duration(0))
+	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), nil, time.Duration(0))
 	assert.True(t, requirement.Passed(nil, nil, nil, nil))
 }
 
 func TestEmptyRequirement_String(t *testing.T) {
-	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), time.Duration(0))
+	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), nil, time.Duration(0))
 	assert.Equal(t, "The empty requirement", requirement.String())
 }
 
 func TestEmptyRequirement_Composite(t *testing.T) {
-	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), time.Duration(0))
+	requirement := (&emptyRequirement{}).Generate(generation.NewRandom(42), nil, time.Duration(0))
 	composite, transcriptType := requirement.Composite()
 	assert.False(t, composite)
 	assert.Equal(t, "empty", transcriptType)
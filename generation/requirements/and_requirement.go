@@ -27,6 +27,7 @@ import (
 
 	"github.com/svenskmand/mimir-lib/generation"
 	gPlacement "github.com/svenskmand/mimir-lib/generation/placement"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	mPlacement "github.com/svenskmand/mimir-lib/model/placement"
 	"github.com/svenskmand/mimir-lib/model/requirements"
 )
@@ -42,10 +43,11 @@ type andRequirementBuilder struct {
 	requirementBuilders []gPlacement.RequirementBuilder
 }
 
-func (builder *andRequirementBuilder) Generate(random generation.Random, time time.Duration) mPlacement.Requirement {
+func (builder *andRequirementBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) mPlacement.Requirement {
 	subRequirements := make([]mPlacement.Requirement, 0, len(builder.requirementBuilders))
 	for _, subBuilder := range builder.requirementBuilders {
-		subRequirement := subBuilder.Generate(random, time)
+		subRequirement := subBuilder.Generate(random, bindings, time)
 		subRequirements = append(subRequirements, subRequirement)
 	}
 	return requirements.NewAndRequirement(subRequirements...)
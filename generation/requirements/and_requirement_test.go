@@ -38,7 +38,7 @@ func TestAndRequirementBuilder_Generate(t *testing.T) {
 			metrics.DiskFree,
 			requirements.GreaterThanEqual,
 			generation.NewConstantGaussian(2.0*metrics.GiB, 0.0)))
-	requirement, ok := builder.Generate(generation.NewRandom(42), time.Duration(0)).(*requirements.AndRequirement)
+	requirement, ok := builder.Generate(generation.NewRandom(42), nil, time.Duration(0)).(*requirements.AndRequirement)
 
 	assert.True(t, ok)
 	assert.Equal(t, 1, len(requirement.Requirements))
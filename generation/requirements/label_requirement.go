@@ -44,22 +44,42 @@ func NewLabelRequirementBuilder(scope, label labels.Template, comparison require
 	}
 }
 
+// NewBoundedLabelRequirementBuilder will create a new label requirement builder with the bounds needed by the
+// comparisons Between and ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not fit
+// the comparison.
+func NewBoundedLabelRequirementBuilder(scope, label labels.Template, comparison requirements.Comparison,
+	occurrences int, bounds *requirements.Bounds) (gPlacement.RequirementBuilder, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &labelRequirementBuilder{
+		scope:       scope,
+		label:       label,
+		comparison:  comparison,
+		occurrences: occurrences,
+		bounds:      bounds,
+	}, nil
+}
+
 type labelRequirementBuilder struct {
 	scope       labels.Template
 	label       labels.Template
 	comparison  requirements.Comparison
 	occurrences int
+	bounds      *requirements.Bounds
 }
 
-func (builder *labelRequirementBuilder) Generate(random generation.Random, time time.Duration) mPlacement.Requirement {
+func (builder *labelRequirementBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) mPlacement.Requirement {
 	var scope *labels.Label
 	if builder.scope != nil {
-		scope = builder.scope.Instantiate()
+		scope = labels.Must(builder.scope.Instantiate(bindings))
+	}
+	return &requirements.LabelRequirement{
+		Scope:       scope,
+		Label:       labels.Must(builder.label.Instantiate(bindings)),
+		Comparison:  builder.comparison,
+		Occurrences: builder.occurrences,
+		Bounds:      builder.bounds,
 	}
-	return requirements.NewLabelRequirement(
-		scope,
-		builder.label.Instantiate(),
-		builder.comparison,
-		builder.occurrences,
-	)
 }
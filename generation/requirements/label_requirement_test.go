@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/svenskmand/mimir-lib/generation"
 	"github.com/svenskmand/mimir-lib/model/labels"
+	"github.com/svenskmand/mimir-lib/model/orderings"
 	"github.com/svenskmand/mimir-lib/model/requirements"
 )
 
@@ -41,10 +42,25 @@ func TestLabelRequirementBuilder_Generate(t *testing.T) {
 		requirements.GreaterThanEqual,
 		1,
 	)
-	requirement, ok := builder.Generate(generation.NewRandom(42), time.Duration(0)).(*requirements.LabelRequirement)
+	requirement, ok := builder.Generate(generation.NewRandom(42), nil, time.Duration(0)).(*requirements.LabelRequirement)
 	assert.True(t, ok)
-	assert.Equal(t, scope.Instantiate(), requirement.Scope)
-	assert.Equal(t, label.Instantiate(), requirement.Label)
+	assert.Equal(t, labels.Must(scope.Instantiate(nil)), requirement.Scope)
+	assert.Equal(t, labels.Must(label.Instantiate(nil)), requirement.Label)
 	assert.Equal(t, requirements.GreaterThanEqual, requirement.Comparison)
 	assert.Equal(t, 1, requirement.Occurrences)
 }
+
+func TestBoundedLabelRequirementBuilder_Generate(t *testing.T) {
+	bounds := requirements.NewRange(orderings.NewEndpoint(1, false), orderings.NewEndpoint(3, false))
+	builder, err := NewBoundedLabelRequirementBuilder(nil, labels.NewTemplate("sku", "*"), requirements.Between, 0, bounds)
+	assert.NoError(t, err)
+	requirement, ok := builder.Generate(generation.NewRandom(42), nil, time.Duration(0)).(*requirements.LabelRequirement)
+
+	assert.True(t, ok)
+	assert.Nil(t, requirement.Scope)
+	assert.Equal(t, requirements.Between, requirement.Comparison)
+	assert.Equal(t, bounds, requirement.Bounds)
+
+	_, err = NewBoundedLabelRequirementBuilder(nil, labels.NewTemplate("sku", "*"), requirements.Between, 0, nil)
+	assert.EqualError(t, err, "the comparison between needs a range")
+}
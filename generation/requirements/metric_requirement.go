@@ -27,6 +27,7 @@ import (
 
 	"github.com/svenskmand/mimir-lib/generation"
 	gPlacement "github.com/svenskmand/mimir-lib/generation/placement"
+	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/metrics"
 	mPlacement "github.com/svenskmand/mimir-lib/model/placement"
 	"github.com/svenskmand/mimir-lib/model/requirements"
@@ -43,12 +44,35 @@ func NewMetricRequirementBuilder(metricType metrics.Type, comparison requirement
 	}
 }
 
+// NewBoundedMetricRequirementBuilder will create a new metrics requirement builder with the bounds needed by the
+// comparisons Between and ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not
+// fit the comparison.
+func NewBoundedMetricRequirementBuilder(metricType metrics.Type, comparison requirements.Comparison,
+	value generation.Distribution, bounds *requirements.Bounds) (gPlacement.RequirementBuilder, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &metricRequirementBuilder{
+		metricType: metricType,
+		comparison: comparison,
+		value:      value,
+		bounds:     bounds,
+	}, nil
+}
+
 type metricRequirementBuilder struct {
 	metricType metrics.Type
 	comparison requirements.Comparison
 	value      generation.Distribution
+	bounds     *requirements.Bounds
 }
 
-func (builder *metricRequirementBuilder) Generate(random generation.Random, time time.Duration) mPlacement.Requirement {
-	return requirements.NewMetricRequirement(builder.metricType, builder.comparison, builder.value.Value(random, time))
+func (builder *metricRequirementBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) mPlacement.Requirement {
+	return &requirements.MetricRequirement{
+		MetricType: builder.metricType,
+		Comparison: builder.comparison,
+		Value:      builder.value.Value(random, time),
+		Bounds:     builder.bounds,
+	}
 }
@@ -35,10 +35,23 @@ import (
 func TestMetricRequirementBuilder_Generate(t *testing.T) {
 	builder := NewMetricRequirementBuilder(
 		metrics.DiskFree, requirements.GreaterThanEqual, generation.NewConstantGaussian(2.0*metrics.GiB, 0.0))
-	requirement, ok := builder.Generate(generation.NewRandom(42), time.Duration(0)).(*requirements.MetricRequirement)
+	requirement, ok := builder.Generate(generation.NewRandom(42), nil, time.Duration(0)).(*requirements.MetricRequirement)
 
 	assert.True(t, ok)
 	assert.Equal(t, metrics.DiskFree, requirement.MetricType)
 	assert.Equal(t, requirements.GreaterThanEqual, requirement.Comparison)
 	assert.Equal(t, 2.0*metrics.GiB, requirement.Value)
 }
+
+func TestBoundedMetricRequirementBuilder_Generate(t *testing.T) {
+	bounds := requirements.NewTolerance(0.5 * metrics.GiB)
+	builder, err := NewBoundedMetricRequirementBuilder(
+		metrics.DiskFree, requirements.ApproximatelyEqual, generation.NewConstantGaussian(2.0*metrics.GiB, 0.0), bounds)
+	assert.NoError(t, err)
+	requirement, ok := builder.Generate(generation.NewRandom(42), nil, time.Duration(0)).(*requirements.MetricRequirement)
+
+	assert.True(t, ok)
+	assert.Equal(t, requirements.ApproximatelyEqual, requirement.Comparison)
+	assert.Equal(t, 2.0*metrics.GiB, requirement.Value)
+	assert.Equal(t, bounds, requirement.Bounds)
+}
@@ -35,7 +35,7 @@ import (
 // NewRelationRequirementBuilder will create a new relation requirement builder requiring that the relations occurrences
 // to fulfill the comparison.
 func NewRelationRequirementBuilder(scope, relation labels.Template, comparison requirements.Comparison,
-	occurrences int) gPlacement.RequirementBuilder {
+	occurrences float64) gPlacement.RequirementBuilder {
 	return &relationRequirementBuilder{
 		scope:       scope,
 		relation:    relation,
@@ -44,22 +44,42 @@ func NewRelationRequirementBuilder(scope, relation labels.Template, comparison r
 	}
 }
 
+// NewBoundedRelationRequirementBuilder will create a new relation requirement builder with the bounds needed by the
+// comparisons Between and ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not fit
+// the comparison.
+func NewBoundedRelationRequirementBuilder(scope, relation labels.Template, comparison requirements.Comparison,
+	occurrences float64, bounds *requirements.Bounds) (gPlacement.RequirementBuilder, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &relationRequirementBuilder{
+		scope:       scope,
+		relation:    relation,
+		comparison:  comparison,
+		occurrences: occurrences,
+		bounds:      bounds,
+	}, nil
+}
+
 type relationRequirementBuilder struct {
 	scope       labels.Template
 	relation    labels.Template
 	comparison  requirements.Comparison
-	occurrences int
+	occurrences float64
+	bounds      *requirements.Bounds
 }
 
-func (builder *relationRequirementBuilder) Generate(random generation.Random, time time.Duration) mPlacement.Requirement {
+func (builder *relationRequirementBuilder) Generate(random generation.Random, bindings *labels.Bindings,
+	time time.Duration) mPlacement.Requirement {
 	var scope *labels.Label
 	if builder.scope != nil {
-		scope = builder.scope.Instantiate()
+		scope = labels.Must(builder.scope.Instantiate(bindings))
+	}
+	return &requirements.RelationRequirement{
+		Scope:       scope,
+		Relation:    labels.Must(builder.relation.Instantiate(bindings)),
+		Comparison:  builder.comparison,
+		Occurrences: builder.occurrences,
+		Bounds:      builder.bounds,
 	}
-	return requirements.NewRelationRequirement(
-		scope,
-		builder.relation.Instantiate(),
-		builder.comparison,
-		builder.occurrences,
-	)
 }
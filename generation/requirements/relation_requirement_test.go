@@ -41,10 +41,11 @@ func TestRelationRequirementBuilder_Generate(t *testing.T) {
 		requirements.LessThan,
 		1,
 	)
-	requirement, ok := builder.Generate(generation.NewRandom(42), time.Duration(0)).(*requirements.RelationRequirement)
+	generated := builder.Generate(generation.NewRandom(42), nil, time.Duration(0))
+	requirement, ok := generated.(*requirements.RelationRequirement)
 	assert.True(t, ok)
-	assert.Equal(t, scope.Instantiate(), requirement.Scope)
-	assert.Equal(t, relation.Instantiate(), requirement.Relation)
+	assert.Equal(t, labels.Must(scope.Instantiate(nil)), requirement.Scope)
+	assert.Equal(t, labels.Must(relation.Instantiate(nil)), requirement.Relation)
 	assert.Equal(t, requirements.LessThan, requirement.Comparison)
-	assert.Equal(t, 1, requirement.Occurrences)
+	assert.Equal(t, 1.0, requirement.Occurrences)
 }
@@ -25,64 +25,172 @@ package labels
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// Bag represents a bag of labels and their counts, i.e. it is a multi-bag.
+// Bag represents a bag of labels and their counts, i.e. it is a multi-bag. Each label also has a weight which is the
+// sum of the weights it was added with, a label added without a weight has a weight of 1.
+//
+// A bag can be frozen, after which all reads are lock-free until the bag is changed again. Changing a frozen bag will
+// copy its content before changing it, so readers of the frozen content will never observe the change.
+//
+// A label in a bag can have an expiry time after which it should no longer be considered part of the bag, this is
+// useful for transient conditions like issues on a host.
 type Bag struct {
-	bag  map[string]*labelCount
-	lock sync.RWMutex
+	bag   map[string]*labelCount
+	index *index
+	// expiring is true iff a label in the bag has an expiry time.
+	expiring bool
+	// frozen holds the content of the bag while it is frozen and a nil content otherwise.
+	frozen atomic.Value
+	lock   sync.RWMutex
 }
 
 // NewBag will create a new label bag.
 func NewBag() *Bag {
 	return &Bag{
-		bag: map[string]*labelCount{},
+		bag:   map[string]*labelCount{},
+		index: newIndex(nil),
 	}
 }
 
+// content is the labels and counts of a bag together with the index over them.
+type content struct {
+	bag      map[string]*labelCount
+	index    *index
+	expiring bool
+}
+
 type labelCount struct {
-	label *Label
-	count int
+	label  *Label
+	count  int
+	weight float64
+	// expiry is the time the label expires, the zero time means that the label never expires.
+	expiry time.Time
 }
 
 func (pair *labelCount) clone() *labelCount {
 	return &labelCount{
-		label: pair.label,
-		count: pair.count,
+		label:  pair.label,
+		count:  pair.count,
+		weight: pair.weight,
+		expiry: pair.expiry,
+	}
+}
+
+func (pair *labelCount) expired(now time.Time) bool {
+	return !pair.expiry.IsZero() && !now.Before(pair.expiry)
+}
+
+// laterExpiry returns the latest of the two expiry times where the zero time means that the label never expires.
+func laterExpiry(expiry1, expiry2 time.Time) time.Time {
+	if expiry1.IsZero() || expiry2.IsZero() {
+		return time.Time{}
+	}
+	if expiry1.After(expiry2) {
+		return expiry1
+	}
+	return expiry2
+}
+
+// Freeze makes the current content of the bag read-only, which makes reads of the bag lock-free. This is useful for
+// bags that are read from many goroutines during placement. Any later change of the bag will transparently copy the
+// content and thaw the bag.
+func (bag *Bag) Freeze() {
+	bag.lock.Lock()
+	defer bag.lock.Unlock()
+
+	bag.frozen.Store(&content{
+		bag:      bag.bag,
+		index:    bag.index,
+		expiring: bag.expiring,
+	})
+}
+
+// Frozen returns true iff the bag is frozen, i.e. it has been frozen and it has not been changed since.
+func (bag *Bag) Frozen() bool {
+	return bag.frozenContent() != nil
+}
+
+func (bag *Bag) frozenContent() *content {
+	frozen, _ := bag.frozen.Load().(*content)
+	return frozen
+}
+
+// thaw replaces the frozen content of the bag with a private copy that can be changed, readers of the frozen content
+// will keep reading the old content. The write lock of the bag must be held.
+func (bag *Bag) thaw() {
+	if bag.frozenContent() == nil {
+		return
 	}
+	pairs := clonePairs(bag.bag)
+	bag.bag = pairs
+	bag.index = newIndex(pairs)
+	bag.frozen.Store((*content)(nil))
 }
 
 // Size will return the number of different labels in the label bag.
 func (bag *Bag) Size() int {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return len(frozen.bag)
+	}
 	bag.lock.RLock()
 	defer bag.lock.RUnlock()
 
 	return len(bag.bag)
 }
 
-// Add adds a label to this label bag with a count of 1.
+// Add adds a label to this label bag with a count of 1 and a weight of 1.
 func (bag *Bag) Add(labels ...*Label) {
 	bag.lock.Lock()
 	defer bag.lock.Unlock()
+	bag.thaw()
 
 	for _, label := range labels {
-		key := label.String()
-		if oldPair, found := bag.bag[key]; found {
-			oldPair.count++
-		} else {
-			bag.bag[key] = &labelCount{
-				label: label,
-				count: 1,
-			}
-		}
+		bag.add(label, 1)
+	}
+}
+
+// AddWeighted adds a label to this label bag with a count of 1 and the given weight, e.g. a relation with a weight of
+// 0.5 can represent half an instance.
+func (bag *Bag) AddWeighted(label *Label, weight float64) {
+	bag.lock.Lock()
+	defer bag.lock.Unlock()
+	bag.thaw()
+
+	bag.add(label, weight)
+}
+
+func (bag *Bag) add(label *Label, weight float64) {
+	key := label.String()
+	if oldPair, found := bag.bag[key]; found {
+		oldPair.count++
+		oldPair.weight += weight
+		return
+	}
+	pair := &labelCount{
+		label:  label,
+		count:  1,
+		weight: weight,
 	}
+	bag.bag[key] = pair
+	bag.index.insert(pair)
 }
 
 func copyContent(src *Bag) map[string]*labelCount {
+	if frozen := src.frozenContent(); frozen != nil {
+		return clonePairs(frozen.bag)
+	}
 	src.lock.RLock()
 	defer src.lock.RUnlock()
-	dst := make(map[string]*labelCount, len(src.bag))
-	for _, pair := range src.bag {
+
+	return clonePairs(src.bag)
+}
+
+func clonePairs(pairs map[string]*labelCount) map[string]*labelCount {
+	dst := make(map[string]*labelCount, len(pairs))
+	for _, pair := range pairs {
 		dst[pair.label.String()] = pair.clone()
 	}
 	return dst
@@ -94,28 +202,43 @@ func (bag *Bag) AddAll(other *Bag) {
 
 	bag.lock.Lock()
 	defer bag.lock.Unlock()
+	bag.thaw()
 
 	for _, pair := range otherCopy {
+		bag.expiring = bag.expiring || !pair.expiry.IsZero()
 		if oldPair, found := bag.bag[pair.label.String()]; found {
 			oldPair.count += pair.count
+			oldPair.weight += pair.weight
+			oldPair.expiry = laterExpiry(oldPair.expiry, pair.expiry)
 		} else {
 			bag.bag[pair.label.String()] = pair
+			bag.index.insert(pair)
 		}
 	}
 }
 
-// Set adds the value in the label bag and sets it count.
+// Set adds the value in the label bag and sets it count, the weight of the label is set to the count.
 func (bag *Bag) Set(label *Label, count int) {
+	bag.SetWeighted(label, count, float64(count))
+}
+
+// SetWeighted adds the value in the label bag and sets its count and weight.
+func (bag *Bag) SetWeighted(label *Label, count int, weight float64) {
 	bag.lock.Lock()
 	defer bag.lock.Unlock()
+	bag.thaw()
 
 	if oldPair, found := bag.bag[label.String()]; found {
 		oldPair.count = count
+		oldPair.weight = weight
 	} else {
-		bag.bag[label.String()] = &labelCount{
-			label: label,
-			count: count,
+		pair := &labelCount{
+			label:  label,
+			count:  count,
+			weight: weight,
 		}
+		bag.bag[label.String()] = pair
+		bag.index.insert(pair)
 	}
 }
 
@@ -126,12 +249,17 @@ func (bag *Bag) SetAll(other *Bag) {
 
 	bag.lock.Lock()
 	defer bag.lock.Unlock()
+	bag.thaw()
 
 	for _, pair := range otherCopy {
+		bag.expiring = bag.expiring || !pair.expiry.IsZero()
 		if oldPair, found := bag.bag[pair.label.String()]; found {
 			oldPair.count = pair.count
+			oldPair.weight = pair.weight
+			oldPair.expiry = pair.expiry
 		} else {
 			bag.bag[pair.label.String()] = pair
+			bag.index.insert(pair)
 		}
 	}
 }
@@ -152,11 +280,18 @@ func (labels sortedLabels) Swap(i, j int) {
 
 // Labels returns a new slice of all the labels in the bag.
 func (bag *Bag) Labels() []*Label {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return labelsOf(frozen.bag)
+	}
 	bag.lock.RLock()
 	defer bag.lock.RUnlock()
 
-	result := make(sortedLabels, 0, len(bag.bag))
-	for _, pair := range bag.bag {
+	return labelsOf(bag.bag)
+}
+
+func labelsOf(pairs map[string]*labelCount) []*Label {
+	result := make(sortedLabels, 0, len(pairs))
+	for _, pair := range pairs {
 		result = append(result, pair.label)
 	}
 	sort.Sort(result)
@@ -165,27 +300,31 @@ func (bag *Bag) Labels() []*Label {
 
 // Find will find all labels matching the given label.
 func (bag *Bag) Find(label *Label) []*Label {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return find(frozen.bag, frozen.index, label)
+	}
 	bag.lock.RLock()
 	defer bag.lock.RUnlock()
 
+	return find(bag.bag, bag.index, label)
+}
+
+func find(pairs map[string]*labelCount, index *index, label *Label) []*Label {
 	if label.Wildcard() {
-		return bag.findByPattern(label)
+		return findByPattern(index, label)
 	}
 	var result []*Label
-	if pair, exists := bag.bag[label.String()]; exists {
+	if pair, exists := pairs[label.String()]; exists {
 		result = append(result, pair.label)
 	}
 	return result
 }
 
-func (bag *Bag) findByPattern(pattern *Label) []*Label {
+func findByPattern(index *index, pattern *Label) []*Label {
 	var result []*Label
-	for _, pair := range bag.bag {
-		if !pattern.Match(pair.label) {
-			continue
-		}
+	index.visit(pattern, func(pair *labelCount) {
 		result = append(result, pair.label)
-	}
+	})
 	return result
 }
 
@@ -196,26 +335,170 @@ func (bag *Bag) Contains(label *Label) bool {
 
 // Count counts the number of labels that this label matches.
 func (bag *Bag) Count(label *Label) int {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return count(frozen.bag, frozen.index, label)
+	}
 	bag.lock.RLock()
 	defer bag.lock.RUnlock()
 
+	return count(bag.bag, bag.index, label)
+}
+
+func count(pairs map[string]*labelCount, index *index, label *Label) int {
 	if label.Wildcard() {
-		return bag.countByPattern(label)
+		return countByPattern(index, label)
 	}
-	pair, exists := bag.bag[label.String()]
+	pair, exists := pairs[label.String()]
 	if exists {
 		return pair.count
 	}
 	return 0
 }
 
-func (bag *Bag) countByPattern(pattern *Label) int {
+func countByPattern(index *index, pattern *Label) int {
 	counts := 0
-	for _, pair := range bag.bag {
-		if !pattern.Match(pair.label) {
+	index.visit(pattern, func(pair *labelCount) {
+		counts += pair.count
+	})
+	return counts
+}
+
+// Weight sums the weights of the labels that this label matches.
+func (bag *Bag) Weight(label *Label) float64 {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return weight(frozen.bag, frozen.index, label)
+	}
+	bag.lock.RLock()
+	defer bag.lock.RUnlock()
+
+	return weight(bag.bag, bag.index, label)
+}
+
+func weight(pairs map[string]*labelCount, index *index, label *Label) float64 {
+	if label.Wildcard() {
+		weights := 0.0
+		index.visit(label, func(pair *labelCount) {
+			weights += pair.weight
+		})
+		return weights
+	}
+	if pair, exists := pairs[label.String()]; exists {
+		return pair.weight
+	}
+	return 0
+}
+
+// SetExpiry sets the time at which the label in the bag expires, the zero time means that the label never expires.
+// Nothing happens if the label is not in the bag.
+func (bag *Bag) SetExpiry(label *Label, expiry time.Time) {
+	bag.lock.Lock()
+	defer bag.lock.Unlock()
+	bag.thaw()
+
+	if pair, found := bag.bag[label.String()]; found {
+		pair.expiry = expiry
+		bag.expiring = bag.expiring || !expiry.IsZero()
+	}
+}
+
+// Expiry returns the time at which the label in the bag expires and true, or the zero time and false if the label is
+// not in the bag or never expires.
+func (bag *Bag) Expiry(label *Label) (time.Time, bool) {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return expiry(frozen.bag, label)
+	}
+	bag.lock.RLock()
+	defer bag.lock.RUnlock()
+
+	return expiry(bag.bag, label)
+}
+
+func expiry(pairs map[string]*labelCount, label *Label) (time.Time, bool) {
+	pair, found := pairs[label.String()]
+	if !found || pair.expiry.IsZero() {
+		return time.Time{}, false
+	}
+	return pair.expiry, true
+}
+
+// Active returns a bag with the labels of the bag that have not expired at the given time. If no labels in the bag
+// have expired the bag itself is returned, else a new frozen bag is returned.
+func (bag *Bag) Active(now time.Time) *Bag {
+	if frozen := bag.frozenContent(); frozen != nil {
+		return bag.active(frozen.bag, frozen.expiring, now)
+	}
+	bag.lock.RLock()
+	defer bag.lock.RUnlock()
+
+	return bag.active(bag.bag, bag.expiring, now)
+}
+
+func (bag *Bag) active(pairs map[string]*labelCount, expiring bool, now time.Time) *Bag {
+	if !expiring || !anyExpired(pairs, now) {
+		return bag
+	}
+
+	active := make(map[string]*labelCount, len(pairs))
+	for key, pair := range pairs {
+		if !pair.expired(now) {
+			active[key] = pair.clone()
+		}
+	}
+	result := &Bag{
+		bag:      active,
+		index:    newIndex(active),
+		expiring: true,
+	}
+	result.Freeze()
+	return result
+}
+
+// Prune removes all labels from the bag that have expired at the given time and returns the removed labels.
+func (bag *Bag) Prune(now time.Time) []*Label {
+	bag.lock.Lock()
+	defer bag.lock.Unlock()
+	if !bag.expiring || !anyExpired(bag.bag, now) {
+		return nil
+	}
+	bag.thaw()
+
+	var removed sortedLabels
+	expiring := false
+	for key, pair := range bag.bag {
+		if pair.expired(now) {
+			removed = append(removed, pair.label)
+			delete(bag.bag, key)
 			continue
 		}
-		counts += pair.count
+		expiring = expiring || !pair.expiry.IsZero()
+	}
+	bag.expiring = expiring
+	bag.index = newIndex(bag.bag)
+	sort.Sort(removed)
+	return removed
+}
+
+func anyExpired(pairs map[string]*labelCount, now time.Time) bool {
+	for _, pair := range pairs {
+		if pair.expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot returns an immutable copy of the labels and their counts currently in the bag.
+func (bag *Bag) Snapshot() *Snapshot {
+	if frozen := bag.frozenContent(); frozen != nil {
+		// The frozen content is never changed so it can be shared with the snapshot.
+		return &Snapshot{
+			bag:   frozen.bag,
+			index: frozen.index,
+		}
+	}
+	pairs := copyContent(bag)
+	return &Snapshot{
+		bag:   pairs,
+		index: newIndex(pairs),
 	}
-	return counts
 }
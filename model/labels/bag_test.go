@@ -25,6 +25,7 @@ package labels
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,17 @@ func TestBag_Set(t *testing.T) {
 	assert.Equal(t, 2, bag.Count(label2))
 }
 
+func TestBag_SetWeighted(t *testing.T) {
+	bag := NewBag()
+	label := NewLabel("some", "label", "1")
+	bag.Add(label)
+
+	bag.SetWeighted(label, 2, 0.5)
+
+	assert.Equal(t, 2, bag.Count(label))
+	assert.Equal(t, 0.5, bag.Weight(label))
+}
+
 func TestBag_SetAllReplacesAllLabels(t *testing.T) {
 	bag1 := NewBag()
 	bag2 := NewBag()
@@ -173,3 +185,175 @@ func TestBag_CountWillCountAllMatchingLabels(t *testing.T) {
 	assert.Equal(t, 2, bag.Count(pattern1))
 	assert.Equal(t, 4, bag.Count(pattern2))
 }
+
+func TestBag_FreezeKeepsContentReadable(t *testing.T) {
+	bag := NewBag()
+	label := NewLabel("some", "label", "1")
+	bag.Add(label, label)
+	assert.False(t, bag.Frozen())
+
+	bag.Freeze()
+
+	assert.True(t, bag.Frozen())
+	assert.Equal(t, 1, bag.Size())
+	assert.Equal(t, 2, bag.Count(label))
+	assert.Equal(t, 1, len(bag.Find(NewLabel("some", "label", "*"))))
+	assert.Equal(t, 1, len(bag.Labels()))
+}
+
+func TestBag_ChangingAFrozenBagThawsItWithoutChangingSnapshots(t *testing.T) {
+	bag := NewBag()
+	label1 := NewLabel("some", "label", "1")
+	label2 := NewLabel("some", "label", "2")
+	bag.Add(label1)
+	bag.Freeze()
+	snapshot := bag.Snapshot()
+
+	bag.Add(label1, label2)
+
+	assert.False(t, bag.Frozen())
+	assert.Equal(t, 2, bag.Count(label1))
+	assert.Equal(t, 1, bag.Count(label2))
+	assert.Equal(t, 1, snapshot.Count(label1))
+	assert.Equal(t, 0, snapshot.Count(label2))
+}
+
+func TestBag_AddAllFromABagThawedConcurrently(t *testing.T) {
+	src := NewBag()
+	src.Add(NewLabel("rack", "a1"))
+	src.Freeze()
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			src.Add(NewLabel("host", strconv.Itoa(i)))
+			src.Freeze()
+		}
+		done <- true
+	}()
+
+	for i := 0; i < 100; i++ {
+		dst := NewBag()
+		dst.AddAll(src)
+		assert.Equal(t, 1, dst.Count(NewLabel("rack", "a1")))
+	}
+	<-done
+	assert.Equal(t, 101, src.Size())
+}
+
+func TestBag_CountWithWildCardsAfterThaw(t *testing.T) {
+	bag := NewBag()
+	pattern := NewLabel("rack", "*")
+	bag.Add(NewLabel("rack", "a1"))
+	bag.Freeze()
+	bag.Add(NewLabel("rack", "a2"))
+	bag.Set(NewLabel("rack", "a3"), 2)
+
+	assert.Equal(t, 4, bag.Count(pattern))
+	assert.Equal(t, 3, len(bag.Find(pattern)))
+}
+
+func TestBag_FindWithRicherPatterns(t *testing.T) {
+	bag := NewBag()
+	bag.Add(NewLabel("rack", "a1", "host1"))
+	bag.Add(NewLabel("host", "schemadock42"))
+	bag.Add(NewLabel("volume-type", "zfs"))
+	bag.Add(NewLabel("volume-type", "ssd"))
+
+	assert.Equal(t, 1, len(bag.Find(NewLabel("rack", "**"))))
+	assert.Equal(t, 1, len(bag.Find(NewLabel("host", "schemadock4*"))))
+	assert.Equal(t, 1, len(bag.Find(NewLabel("volume-type", "{local,zfs}"))))
+	assert.Equal(t, 4, bag.Count(NewLabel("**")))
+}
+
+func TestBag_SetExpiryAndExpiry(t *testing.T) {
+	bag := NewBag()
+	label := NewLabel("issue", "disk-failure")
+	expiry := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bag.SetExpiry(label, expiry)
+	_, expiring := bag.Expiry(label)
+	assert.False(t, expiring, "labels not in the bag can not expire")
+
+	bag.Add(label)
+	bag.SetExpiry(label, expiry)
+	actual, expiring := bag.Expiry(label)
+	assert.True(t, expiring)
+	assert.Equal(t, expiry, actual)
+}
+
+func TestBag_ActiveIgnoresExpiredLabels(t *testing.T) {
+	bag := NewBag()
+	issue := NewLabel("issue", "disk-failure")
+	rack := NewLabel("rack", "a1")
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	bag.Add(issue, rack)
+	assert.True(t, bag.Active(now) == bag)
+
+	bag.SetExpiry(issue, now.Add(time.Minute))
+	assert.True(t, bag.Active(now) == bag)
+
+	active := bag.Active(now.Add(time.Minute))
+	assert.True(t, active.Frozen())
+	assert.False(t, active.Contains(issue))
+	assert.True(t, active.Contains(rack))
+	assert.Equal(t, 0, active.Count(NewLabel("issue", "*")))
+	assert.True(t, bag.Contains(issue))
+}
+
+func TestBag_PruneRemovesExpiredLabels(t *testing.T) {
+	bag := NewBag()
+	issue := NewLabel("issue", "disk-failure")
+	rack := NewLabel("rack", "a1")
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	bag.Add(issue, rack)
+	bag.SetExpiry(issue, now.Add(time.Minute))
+
+	assert.Empty(t, bag.Prune(now))
+	assert.Equal(t, []*Label{issue}, bag.Prune(now.Add(time.Hour)))
+	assert.False(t, bag.Contains(issue))
+	assert.Equal(t, 0, bag.Count(NewLabel("issue", "*")))
+	assert.True(t, bag.Contains(rack))
+}
+
+func TestBag_AddAllKeepsTheLatestExpiry(t *testing.T) {
+	issue := NewLabel("issue", "disk-failure")
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	bag1 := NewBag()
+	bag1.Add(issue)
+	bag1.SetExpiry(issue, now.Add(time.Minute))
+	bag2 := NewBag()
+	bag2.Add(issue)
+	bag2.SetExpiry(issue, now.Add(time.Hour))
+	bag3 := NewBag()
+	bag3.Add(issue)
+
+	bag1.AddAll(bag2)
+	expiry, _ := bag1.Expiry(issue)
+	assert.Equal(t, now.Add(time.Hour), expiry)
+
+	bag1.AddAll(bag3)
+	_, expiring := bag1.Expiry(issue)
+	assert.False(t, expiring)
+}
+
+func TestBag_WeightSumsTheWeightsOfMatchingLabels(t *testing.T) {
+	bag := NewBag()
+	label1 := NewLabel("redis", "instance", "store1")
+	label2 := NewLabel("redis", "instance", "store2")
+	bag.AddWeighted(label1, 0.5)
+	bag.AddWeighted(label1, 0.25)
+	bag.Add(label2)
+
+	assert.Equal(t, 2, bag.Count(label1))
+	assert.Equal(t, 0.75, bag.Weight(label1))
+	assert.Equal(t, 1.0, bag.Weight(label2))
+	assert.Equal(t, 1.75, bag.Weight(NewLabel("redis", "instance", "*")))
+	assert.Equal(t, 0.0, bag.Weight(NewLabel("redis", "instance", "store3")))
+
+	other := NewBag()
+	other.AddAll(bag)
+	assert.Equal(t, 1.75, other.Snapshot().Weight(NewLabel("redis", "**")))
+
+	bag.Set(label1, 3)
+	assert.Equal(t, 3.0, bag.Weight(label1))
+}
@@ -28,23 +28,42 @@ import (
 
 // Label represents an immutable label which consists of a list of names. Since a label is a list of names the label
 // have a hierarchy, e.g. the labels foo.bar and foo.baz can be thought of as being nested under the label foo.*.
+//
+// A label can be used as a pattern by using wildcards in its names:
+//   - * matches any single name, e.g. rack.* matches rack.a1 but not rack.a1.host1.
+//   - ** matches any number of names including none, e.g. rack.** matches rack, rack.a1 and rack.a1.host1.
+//   - * and ? within a name matches any number of characters or a single character, e.g. host.schemadock4* matches
+//     host.schemadock42.
+//   - {a,b} within a name matches either a or b, e.g. volume-type.{local,zfs} matches volume-type.zfs.
+//   - a backslash in front of one of the characters *?{},\ makes the character match itself, e.g. host.web\*01 does
+//     not match host.web101. Use Escape for names which should never be used as patterns, e.g. names from user input.
 type Label struct {
 	// names is a list of names, e.g. foo, bar and baz
 	names []string
+	// matchers is the list of compiled names
+	matchers []*matcher
 	// simpleName is a concatenation of names with . in between, e.g. foo.bar.baz
 	simpleName string
 	// wildcard is true iff the label contains a wildcard
 	wildcard bool
+	// multiWildcard is true iff the label contains a ** wildcard
+	multiWildcard bool
 }
 
 // NewLabel creates a new label from the given names.
 func NewLabel(names ...string) *Label {
-	simpleName := strings.Join(names, ".")
-	return &Label{
+	label := &Label{
 		names:      names,
-		simpleName: simpleName,
-		wildcard:   strings.Contains(simpleName, "*"),
+		matchers:   make([]*matcher, 0, len(names)),
+		simpleName: strings.Join(names, "."),
+	}
+	for _, name := range names {
+		compiled := newMatcher(name)
+		label.matchers = append(label.matchers, compiled)
+		label.wildcard = label.wildcard || compiled.kind != literal
+		label.multiWildcard = label.multiWildcard || compiled.kind == multiWildcard
 	}
+	return label
 }
 
 // Wildcard returns true iff the label contains a wildcard.
@@ -64,13 +83,6 @@ func (label *Label) String() string {
 	return label.simpleName
 }
 
-func (label *Label) nameMatch(name1, name2 string) bool {
-	if name1 == "*" || name2 == "*" {
-		return true
-	}
-	return name1 == name2
-}
-
 // Match returns true iff the label matches the other label or vice versa taking wildcards into account.
 // When matching one label to another label then a wildcard will match any name in the other label at the same position.
 func (label *Label) Match(other *Label) bool {
@@ -80,11 +92,14 @@ func (label *Label) Match(other *Label) bool {
 	if !label.wildcard && !other.wildcard {
 		return label.simpleName == other.simpleName
 	}
-	if len(label.names) != len(other.names) {
+	if label.multiWildcard || other.multiWildcard {
+		return matchAll(label.matchers, other.matchers)
+	}
+	if len(label.matchers) != len(other.matchers) {
 		return false
 	}
-	for i := range label.names {
-		if !label.nameMatch(label.names[i], other.names[i]) {
+	for i := range label.matchers {
+		if !label.matchers[i].match(other.matchers[i]) {
 			return false
 		}
 	}
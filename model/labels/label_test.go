@@ -100,3 +100,72 @@ func TestLabel_Match_ReturnsTrueWhenSamePointer(t *testing.T) {
 	label := NewLabel("foo", "bar", "baz")
 	assert.True(t, label.Match(label))
 }
+
+func TestLabel_Match_WithMultiSegmentWildcard(t *testing.T) {
+	pattern := NewLabel("rack", "**")
+	assert.True(t, pattern.Wildcard())
+
+	assert.True(t, pattern.Match(NewLabel("rack")))
+	assert.True(t, pattern.Match(NewLabel("rack", "a1")))
+	assert.True(t, pattern.Match(NewLabel("rack", "a1", "host1")))
+	assert.False(t, pattern.Match(NewLabel("zone", "a1")))
+	assert.True(t, NewLabel("**", "host1").Match(NewLabel("rack", "a1", "host1")))
+	assert.False(t, NewLabel("**", "host1").Match(NewLabel("rack", "a1", "host2")))
+}
+
+func TestLabel_Match_WithGlobWithinName(t *testing.T) {
+	pattern := NewLabel("host", "schemadock4*")
+	assert.True(t, pattern.Wildcard())
+
+	assert.True(t, pattern.Match(NewLabel("host", "schemadock4")))
+	assert.True(t, pattern.Match(NewLabel("host", "schemadock42")))
+	assert.False(t, pattern.Match(NewLabel("host", "schemadock5")))
+	assert.True(t, NewLabel("host", "schemadock?").Match(NewLabel("host", "schemadock5")))
+	assert.False(t, NewLabel("host", "schemadock?").Match(NewLabel("host", "schemadock42")))
+	assert.True(t, NewLabel("host", "schema.dock").Match(NewLabel("host", "schema.dock")))
+	assert.False(t, NewLabel("host", "schema.d?ck").Match(NewLabel("host", "schemaXdock")))
+}
+
+func TestLabel_Match_WithAlternation(t *testing.T) {
+	pattern := NewLabel("volume-type", "{local,zfs}")
+	assert.True(t, pattern.Wildcard())
+
+	assert.True(t, pattern.Match(NewLabel("volume-type", "local")))
+	assert.True(t, pattern.Match(NewLabel("volume-type", "zfs")))
+	assert.False(t, pattern.Match(NewLabel("volume-type", "ssd")))
+	assert.True(t, NewLabel("volume-type", "{local,zfs}-{1,2}").Match(NewLabel("volume-type", "zfs-2")))
+}
+
+func TestLabel_Match_WithEscapedGlobCharactersIsLiteral(t *testing.T) {
+	literal := NewLabel("host", Escape("web*0?"))
+	assert.Equal(t, `host.web\*0\?`, literal.String())
+	assert.False(t, literal.Wildcard())
+
+	assert.True(t, literal.Match(NewLabel("host", `web\*0\?`)))
+	assert.False(t, literal.Match(NewLabel("host", "web101")))
+	assert.False(t, literal.Match(NewLabel("host", "web01")))
+	assert.True(t, NewLabel("host", "web*").Match(literal))
+	assert.True(t, NewLabel("volume-type", `\{local,zfs}`).Match(NewLabel("volume-type", Escape("{local,zfs}"))))
+	assert.False(t, NewLabel("volume-type", `\{local,zfs}`).Match(NewLabel("volume-type", "zfs")))
+	assert.True(t, NewLabel("volume-type", `{lo\,cal,zfs}`).Match(NewLabel("volume-type", "lo,cal")))
+	assert.True(t, NewLabel("path", `c:\temp`).Match(NewLabel("path", `c:\temp`)))
+	assert.True(t, NewLabel("host", `web\*?`).Match(NewLabel("host", "web*1")))
+	assert.False(t, NewLabel("host", `web\*?`).Match(NewLabel("host", "web11")))
+}
+
+func TestBag_Count_WithEscapedGlobCharactersMatchesExactly(t *testing.T) {
+	bag := NewBag()
+	bag.Add(NewLabel("host", Escape("web*01")), NewLabel("host", "web101"))
+
+	assert.Equal(t, 1, bag.Count(NewLabel("host", Escape("web*01"))))
+	assert.Equal(t, 0, bag.Count(NewLabel("host", "web01")))
+	assert.Equal(t, 2, bag.Count(NewLabel("host", "web?01")))
+}
+
+func TestLabel_Match_WithUnbalancedBracesIsLiteral(t *testing.T) {
+	pattern := NewLabel("volume-type", "{local")
+	assert.False(t, pattern.Wildcard())
+
+	assert.True(t, pattern.Match(NewLabel("volume-type", "{local")))
+	assert.False(t, pattern.Match(NewLabel("volume-type", "local")))
+}
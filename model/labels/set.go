@@ -24,12 +24,8 @@ package labels
 
 import "sync"
 
-// TemplateSet represents a set of label templates where the variables of all templates can be bound for all label
-// templates.
+// TemplateSet represents a set of label templates, it can be used to find the variables used by all the templates.
 type TemplateSet interface {
-	// Bind will bind the variable with the given name to the given value.
-	Bind(name, value string) TemplateSet
-
 	// Add will add a label template whose variables will be set by this variable set.
 	Add(template Template) TemplateSet
 
@@ -39,12 +35,15 @@ type TemplateSet interface {
 	// Templates returns all templates of the template set.
 	Templates() []Template
 
-	// Mappings will return a map of all the current variables and their values.
-	Mappings() map[string]string
+	// Mappings will return a map of all the variables of all templates and their values in the bindings.
+	Mappings(bindings *Bindings) map[string]string
+
+	// Missing returns the sorted names of all variables of all templates which are neither bound in the bindings nor
+	// have a default value.
+	Missing(bindings *Bindings) []string
 }
 
-// NewTemplateSet will create a new template set where templates can be added and variables can be bound for all
-// templates at the same time.
+// NewTemplateSet will create a new template set where templates can be added.
 func NewTemplateSet() TemplateSet {
 	return &templateSet{
 		templates: []Template{},
@@ -56,16 +55,6 @@ type templateSet struct {
 	lock      sync.Mutex
 }
 
-func (set *templateSet) Bind(name, value string) TemplateSet {
-	defer set.lock.Unlock()
-	set.lock.Lock()
-
-	for _, template := range set.templates {
-		template.Bind(name, value)
-	}
-	return set
-}
-
 func (set *templateSet) Add(template Template) TemplateSet {
 	defer set.lock.Unlock()
 	set.lock.Lock()
@@ -95,15 +84,28 @@ func (set *templateSet) Templates() []Template {
 	return templates
 }
 
-func (set *templateSet) Mappings() map[string]string {
+func (set *templateSet) Mappings(bindings *Bindings) map[string]string {
 	defer set.lock.Unlock()
 	set.lock.Lock()
 
 	mappings := map[string]string{}
 	for _, template := range set.templates {
-		for variable, value := range template.Mappings() {
+		for variable, value := range template.Mappings(bindings) {
 			mappings[variable] = value
 		}
 	}
 	return mappings
 }
+
+func (set *templateSet) Missing(bindings *Bindings) []string {
+	defer set.lock.Unlock()
+	set.lock.Lock()
+
+	missing := map[string]bool{}
+	for _, template := range set.templates {
+		for _, variable := range template.Missing(bindings) {
+			missing[variable] = true
+		}
+	}
+	return sortedNames(missing)
+}
@@ -28,14 +28,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestTemplateSet_Bind(t *testing.T) {
-	template := NewTemplate("foo", "$bar$", "$baz$")
-	variables := NewTemplateSet()
-	variables.Add(template).Bind("bar", "bar")
-
-	assert.Equal(t, "foo.bar.$baz$", template.Instantiate().String())
-}
-
 func TestTemplateSet_AddAll(t *testing.T) {
 	template1 := NewTemplate("1")
 	template2 := NewTemplate("2")
@@ -59,9 +51,19 @@ func TestTemplateSet_Templates(t *testing.T) {
 }
 
 func TestTemplateSet_Mappings(t *testing.T) {
-	template := NewTemplate("foo", "$bar$", "$baz$")
-	template.Bind("bar", "bar")
-	variables := NewTemplateSet().Add(template)
+	template1 := NewTemplate("foo", "$bar$")
+	template2 := NewTemplate("$baz$", "$dc:dc1$")
+	variables := NewTemplateSet().Add(template1).Add(template2)
+	bindings := NewBindings().Bind("bar", "bar")
+
+	assert.Equal(t, map[string]string{"bar": "bar", "baz": "", "dc": "dc1"}, variables.Mappings(bindings))
+}
+
+func TestTemplateSet_Missing(t *testing.T) {
+	template1 := NewTemplate("foo", "$bar$")
+	template2 := NewTemplate("$baz$", "$bar$", "$dc:dc1$")
+	variables := NewTemplateSet().Add(template1).Add(template2)
 
-	assert.Equal(t, template.Mappings(), variables.Mappings())
+	assert.Equal(t, []string{"bar", "baz"}, variables.Missing(nil))
+	assert.Equal(t, []string{"baz"}, variables.Missing(NewBindings().Bind("bar", "bar")))
 }
@@ -24,82 +24,232 @@ package labels
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
-	"sync"
 )
 
-// Template represents a label template which can be instantiated with different values. A template can create
-// labels like schemaless.cluster.percona-cluster-$instance-name$-$zone$-db$cluster$ where the labelTemplate substrings
-// <instance-name>, <zone> and <cluster> can then be bound later, and re-bound to instantiate different labels.
+// Template represents an immutable label template which can be instantiated with different values. A template can
+// create labels like schemaless.cluster.percona-cluster-$instance-name$-$zone$-db$cluster$ where the labelTemplate
+// substrings <instance-name>, <zone> and <cluster> are given by the bindings used to instantiate the template, so
+// the same template can instantiate different labels from many goroutines at the same time.
+//
+// A variable can have a default value which is used when the variable is not bound, e.g. $dc:dc1$, and a list of
+// transforms which are applied to the value, e.g. $zone|upper$ or $cluster:1|%02d$. The supported transforms are:
+//   - lower which converts the value to lower case.
+//   - upper which converts the value to upper case.
+//   - a fmt format with a single verb like %02d or %.1f which formats the value as a number, or like %s or %.3s which
+//     formats the value as a string. The verbs d, x, X, o and b format the value as an integer, e, E, f, F, g and G as
+//     a float and s, q and v as a string, other verbs are not supported. The format can have text around the verb,
+//     e.g. db-%02d.
 type Template interface {
-	// Bind will bind the template with the given name to the given value.
-	Bind(name, value string) Template
+	// Mappings will return a map of all the variables of the template and their values in the bindings, variables
+	// that are not bound will have their default value or the empty string if they have no default.
+	Mappings(bindings *Bindings) map[string]string
+
+	// Missing returns the sorted names of all variables which are neither bound in the bindings nor have a default
+	// value.
+	Missing(bindings *Bindings) []string
 
-	// Mappings will return a map of all the current variables and their values.
-	Mappings() map[string]string
+	// Instantiate will create a new label where all templates have been replaced with their value in the bindings,
+	// it will fail if a variable is missing or if a transform can not be applied.
+	Instantiate(bindings *Bindings) (*Label, error)
+}
 
-	// Instantiate will create a new label where all templates have been replaced with their currently bound value.
-	Instantiate() *Label
+// Must returns the label if there is no error and panics otherwise. It is intended for use with Instantiate on
+// templates which are known to have all their variables bound.
+func Must(label *Label, err error) *Label {
+	if err != nil {
+		panic(err)
+	}
+	return label
 }
 
 // NewTemplate will create a new label template which can be used to create labels with. Each name in the slice of
-// supplied names can use template substrings like percona-cluster-$instance-name$-$zone$-db$cluster$ and then later
-// bind the template names <instance-name>, <zone> and <cluster> using the bind method.
+// supplied names can use template substrings like percona-cluster-$instance-name$-$zone$-db$cluster$ and then bind
+// the template names <instance-name>, <zone> and <cluster> when instantiating the template.
 func NewTemplate(names ...string) Template {
 	return &labelTemplate{
-		names:     names,
-		variables: map[string]string{},
+		names: names,
 	}
 }
 
 type labelTemplate struct {
-	names     []string
-	variables map[string]string
-	lock      sync.Mutex
+	names []string
+}
+
+// expression represents a variable in a template with an optional default value and a list of transforms, i.e. the
+// expression $dc:dc1|upper$ has the variable dc, the default value dc1 and the transform upper.
+type expression struct {
+	variable     string
+	defaultValue *string
+	transforms   []string
 }
 
-func (template *labelTemplate) replace(name string) string {
-	for variable, value := range template.variables {
-		v := fmt.Sprintf("$%v$", variable)
-		if strings.Contains(name, v) {
-			name = strings.Replace(name, v, value, -1)
+func parseExpression(text string) *expression {
+	parts := strings.Split(text, "|")
+	result := &expression{
+		variable:   parts[0],
+		transforms: parts[1:],
+	}
+	if index := strings.Index(result.variable, ":"); index >= 0 {
+		defaultValue := result.variable[index+1:]
+		result.variable = result.variable[:index]
+		result.defaultValue = &defaultValue
+	}
+	return result
+}
+
+// expressions returns the expressions of all variables used in the name.
+func expressions(name string) []*expression {
+	var result []*expression
+	parts := strings.Split(name, "$")
+	for i, part := range parts {
+		// A variable foo should always be used as $foo$ so every other string in the above split is a variable name,
+		// unless it is the last string in which case the variable was never terminated.
+		if i%2 != 1 || i == len(parts)-1 {
+			continue
 		}
+		result = append(result, parseExpression(part))
+	}
+	return result
+}
+
+func transform(value, transform string) (string, error) {
+	switch {
+	case transform == "lower":
+		return strings.ToLower(value), nil
+	case transform == "upper":
+		return strings.ToUpper(value), nil
+	case strings.Contains(transform, "%"):
+		return format(value, transform)
 	}
-	return name
+	return "", fmt.Errorf("unknown transform '%v'", transform)
 }
 
-func (template *labelTemplate) Instantiate() *Label {
-	defer template.lock.Unlock()
-	template.lock.Lock()
+// format formats the value with a fmt format with a single verb, the verb decides if the value is formatted as an
+// integer, a float or a string.
+func format(value, format string) (string, error) {
+	if strings.Count(format, "%") != 1 {
+		return "", fmt.Errorf("unsupported format '%v', it must have exactly one verb", format)
+	}
+	verb := formatVerb(format)
+	switch verb {
+	case 's', 'q', 'v':
+		return fmt.Sprintf(format, value), nil
+	case 'd', 'x', 'X', 'o', 'b', 'e', 'E', 'f', 'F', 'g', 'G':
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("can not format value '%v' as a number", value)
+		}
+		if strings.IndexByte("dxXob", verb) >= 0 {
+			return fmt.Sprintf(format, int64(number)), nil
+		}
+		return fmt.Sprintf(format, number), nil
+	}
+	if verb == 0 {
+		return "", fmt.Errorf("unsupported format '%v', it must have exactly one verb", format)
+	}
+	return "", fmt.Errorf("unsupported format verb '%c' in '%v'", verb, format)
+}
 
-	names := make([]string, len(template.names))
-	for i, name := range template.names {
-		names[i] = template.replace(name)
+// formatVerb returns the verb of the single directive in the format by skipping the flags, width and precision after
+// the %, it returns 0 if the format ends before the verb.
+func formatVerb(format string) byte {
+	i := strings.IndexByte(format, '%') + 1
+	for i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0 {
+		i++
+	}
+	if i == len(format) {
+		return 0
 	}
-	return NewLabel(names...)
+	return format[i]
 }
 
-func (template *labelTemplate) Bind(name, value string) Template {
-	defer template.lock.Unlock()
-	template.lock.Lock()
+func (template *labelTemplate) value(bindings *Bindings, expression *expression) (string, error) {
+	value, bound := bindings.Lookup(expression.variable)
+	if !bound {
+		if expression.defaultValue == nil {
+			return "", fmt.Errorf("variable '%v' of template %v is not bound", expression.variable,
+				strings.Join(template.names, "."))
+		}
+		value = *expression.defaultValue
+	}
+	for _, name := range expression.transforms {
+		var err error
+		if value, err = transform(value, name); err != nil {
+			return "", fmt.Errorf("variable '%v' of template %v: %v", expression.variable,
+				strings.Join(template.names, "."), err)
+		}
+	}
+	return value, nil
+}
 
-	template.variables[name] = value
-	return template
+func (template *labelTemplate) replace(bindings *Bindings, name string) (string, error) {
+	parts := strings.Split(name, "$")
+	var result strings.Builder
+	for i, part := range parts {
+		switch {
+		case i%2 == 0:
+			result.WriteString(part)
+		case i == len(parts)-1:
+			// The variable was never terminated so we keep the text as it is.
+			result.WriteString("$")
+			result.WriteString(part)
+		default:
+			value, err := template.value(bindings, parseExpression(part))
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(value)
+		}
+	}
+	return result.String(), nil
 }
 
-func (template *labelTemplate) Mappings() map[string]string {
-	defer template.lock.Unlock()
-	template.lock.Lock()
+func (template *labelTemplate) Instantiate(bindings *Bindings) (*Label, error) {
+	names := make([]string, len(template.names))
+	for i, name := range template.names {
+		replaced, err := template.replace(bindings, name)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = replaced
+	}
+	return NewLabel(names...), nil
+}
 
-	mappings := make(map[string]string, len(template.variables))
+func (template *labelTemplate) Mappings(bindings *Bindings) map[string]string {
+	mappings := map[string]string{}
 	for _, name := range template.names {
-		for i, variable := range strings.Split(name, "$") {
-			// A variable foo should always be used as $foo$ so every other string in the above split is a variable name
-			if i%2 != 1 {
-				continue
+		for _, expression := range expressions(name) {
+			value, bound := bindings.Lookup(expression.variable)
+			if !bound && expression.defaultValue != nil {
+				value = *expression.defaultValue
 			}
-			mappings[variable] = template.variables[variable]
+			mappings[expression.variable] = value
 		}
 	}
 	return mappings
 }
+
+func (template *labelTemplate) Missing(bindings *Bindings) []string {
+	missing := map[string]bool{}
+	for _, name := range template.names {
+		for _, expression := range expressions(name) {
+			if _, bound := bindings.Lookup(expression.variable); !bound && expression.defaultValue == nil {
+				missing[expression.variable] = true
+			}
+		}
+	}
+	return sortedNames(missing)
+}
+
+func sortedNames(names map[string]bool) []string {
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -23,6 +23,7 @@
 package labels
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,22 +31,102 @@ import (
 
 func TestTemplate(t *testing.T) {
 	template := NewTemplate("foo", "$bar$", "$baz$")
-	label1 := template.Instantiate()
+	_, err := template.Instantiate(nil)
+	assert.EqualError(t, err, "variable 'bar' of template foo.$bar$.$baz$ is not bound")
 
-	assert.Equal(t, "foo.$bar$.$baz$", label1.String())
+	bindings := NewBindings().Bind("bar", "bar")
+	_, err = template.Instantiate(bindings)
+	assert.EqualError(t, err, "variable 'baz' of template foo.$bar$.$baz$ is not bound")
 
-	template.Bind("bar", "bar")
-	label2 := template.Instantiate()
-	assert.Equal(t, "foo.bar.$baz$", label2.String())
+	label, err := template.Instantiate(bindings.Bind("baz", "baz"))
+	assert.NoError(t, err)
+	assert.Equal(t, "foo.bar.baz", label.String())
+}
+
+func TestTemplate_Instantiate_with_defaults(t *testing.T) {
+	template := NewTemplate("datacenter", "$dc:dc1$")
+	assert.Equal(t, "datacenter.dc1", Must(template.Instantiate(nil)).String())
+
+	bindings := NewBindings().Bind("dc", "dc2")
+	assert.Equal(t, "datacenter.dc2", Must(template.Instantiate(bindings)).String())
+}
+
+func TestTemplate_Instantiate_with_transforms(t *testing.T) {
+	template := NewTemplate("cluster", "percona-$name|lower$-$zone:us1|upper$-db$cluster|%02d$")
+	bindings := NewBindings().Bind("name", "Mezzanine")
+
+	assert.Equal(t, "cluster.percona-mezzanine-US1-db07",
+		Must(template.Instantiate(bindings.Bind("cluster", "7"))).String())
 
-	template.Bind("baz", "baz")
-	label3 := template.Instantiate()
-	assert.Equal(t, "foo.bar.baz", label3.String())
+	_, err := template.Instantiate(bindings.Bind("cluster", "seven"))
+	assert.EqualError(t, err, "variable 'cluster' of template cluster.percona-$name|lower$-$zone:us1|upper$-"+
+		"db$cluster|%02d$: can not format value 'seven' as a number")
+
+	_, err = NewTemplate("$name|reverse$").Instantiate(NewBindings().Bind("name", "foo"))
+	assert.EqualError(t, err, "variable 'name' of template $name|reverse$: unknown transform 'reverse'")
+}
+
+func TestTemplate_Instantiate_with_format_verbs(t *testing.T) {
+	bindings := NewBindings().Bind("zone", "us1").Bind("ratio", "0.25")
+
+	assert.Equal(t, "zone.us1", Must(NewTemplate("zone", "$zone|%s$").Instantiate(bindings)).String())
+	assert.Equal(t, "zone.us", Must(NewTemplate("zone", "$zone|%.2v$").Instantiate(bindings)).String())
+	assert.Equal(t, "ratio.2.50e-01", Must(NewTemplate("ratio", "$ratio|%.2e$").Instantiate(bindings)).String())
+	assert.Equal(t, "zone.us1-box", Must(NewTemplate("zone", "$zone|%s-box$").Instantiate(bindings)).String())
+	assert.Equal(t, "host.db-07.local", Must(NewTemplate("host", "$db:7|db-%02d.local$").Instantiate(bindings)).String())
+
+	_, err := NewTemplate("$zone|%c$").Instantiate(bindings)
+	assert.EqualError(t, err, "variable 'zone' of template $zone|%c$: unsupported format verb 'c' in '%c'")
+	_, err = NewTemplate("$zone|%s-bo%c$").Instantiate(bindings)
+	assert.EqualError(t, err, "variable 'zone' of template $zone|%s-bo%c$: unsupported format '%s-bo%c', it must "+
+		"have exactly one verb")
+	_, err = NewTemplate("$zone|zone-%02$").Instantiate(bindings)
+	assert.EqualError(t, err, "variable 'zone' of template $zone|zone-%02$: unsupported format 'zone-%02', it must "+
+		"have exactly one verb")
+	_, err = NewTemplate("$zone|%s%s$").Instantiate(bindings)
+	assert.EqualError(t, err, "variable 'zone' of template $zone|%s%s$: unsupported format '%s%s', it must have "+
+		"exactly one verb")
+}
+
+func TestTemplate_Instantiate_keeps_unterminated_variables(t *testing.T) {
+	template := NewTemplate("price", "$5")
+
+	assert.Equal(t, "price.$5", Must(template.Instantiate(nil)).String())
+}
+
+func TestTemplate_Instantiate_concurrently_with_different_bindings(t *testing.T) {
+	template := NewTemplate("host", "$host$")
+	results := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		go func(i int) {
+			bindings := NewBindings().Bind("host", strconv.Itoa(i))
+			results <- Must(template.Instantiate(bindings)).String()
+		}(i)
+	}
+
+	found := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		found[<-results] = true
+	}
+	assert.Equal(t, 10, len(found))
 }
 
 func TestTemplate_Mappings(t *testing.T) {
-	template := NewTemplate("foo", "$bar$", "$baz$")
-	template.Bind("bar", "bar")
+	template := NewTemplate("foo", "$bar$", "$baz$", "$dc:dc1$")
+	bindings := NewBindings().Bind("bar", "bar")
+
+	assert.Equal(t, map[string]string{"bar": "bar", "baz": "", "dc": "dc1"}, template.Mappings(bindings))
+}
+
+func TestTemplate_Missing(t *testing.T) {
+	template := NewTemplate("$foo$", "$bar|upper$", "$baz$", "$dc:dc1$")
+	bindings := NewBindings().Bind("bar", "bar")
+
+	assert.Equal(t, []string{"baz", "foo"}, template.Missing(bindings))
+}
 
-	assert.Equal(t, map[string]string{"bar": "bar", "baz": ""}, template.Mappings())
+func TestMust_panics_on_error(t *testing.T) {
+	assert.Panics(t, func() {
+		Must(NewTemplate("$foo$").Instantiate(nil))
+	})
 }
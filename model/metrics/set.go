@@ -25,14 +25,20 @@ package metrics
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 // Set represents some metric on a group which represents a host or zone or entity which represents a process,
 // container or task that is running on a group. This can be, but is not limited to, cpu load, memory size, disk size,
 // network bandwidth, estimated disk usage per day, etc.
+//
+// A set can be frozen, after which all reads are lock-free until the set is changed again. Changing a frozen set will
+// copy its content before changing it, so readers of the frozen content will never observe the change.
 type Set struct {
-	set  map[Type]float64
-	lock sync.RWMutex
+	set map[Type]float64
+	// frozen holds the content of the set while it is frozen and a nil map otherwise.
+	frozen atomic.Value
+	lock   sync.RWMutex
 }
 
 // NewSet will create a new metric set.
@@ -42,8 +48,41 @@ func NewSet() *Set {
 	}
 }
 
+// Freeze makes the current content of the set read-only, which makes reads of the set lock-free. This is useful for
+// sets that are read from many goroutines during placement. Any later change of the set will transparently copy the
+// content and thaw the set.
+func (set *Set) Freeze() {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	set.frozen.Store(set.set)
+}
+
+// Frozen returns true iff the set is frozen, i.e. it has been frozen and it has not been changed since.
+func (set *Set) Frozen() bool {
+	return set.frozenContent() != nil
+}
+
+func (set *Set) frozenContent() map[Type]float64 {
+	content, _ := set.frozen.Load().(map[Type]float64)
+	return content
+}
+
+// thaw replaces the frozen content of the set with a private copy that can be changed, readers of the frozen content
+// will keep reading the old content. The write lock of the set must be held.
+func (set *Set) thaw() {
+	if set.frozenContent() == nil {
+		return
+	}
+	set.set = copyContent(set, false)
+	set.frozen.Store(map[Type]float64(nil))
+}
+
 // Size will return the number of different metrics in the metric set.
 func (set *Set) Size() int {
+	if content := set.frozenContent(); content != nil {
+		return len(content)
+	}
 	set.lock.RLock()
 	defer set.lock.RUnlock()
 
@@ -54,6 +93,7 @@ func (set *Set) Size() int {
 func (set *Set) Add(metricType Type, value float64) {
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	old := set.set[metricType]
 	set.set[metricType] = old + value
@@ -63,12 +103,16 @@ func (set *Set) Add(metricType Type, value float64) {
 func (set *Set) Set(metricType Type, value float64) {
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	set.set[metricType] = value
 }
 
 // Get returns the value of a metric type.
 func (set *Set) Get(metricType Type) float64 {
+	if content := set.frozenContent(); content != nil {
+		return content[metricType]
+	}
 	set.lock.RLock()
 	defer set.lock.RUnlock()
 
@@ -92,11 +136,18 @@ func (sorted sortedMetricTypes) Swap(i, j int) {
 // Types returns a new slice of all metric types in the set.The list of types is sorted by the metric type names in
 // ascending order.
 func (set *Set) Types() []Type {
+	if content := set.frozenContent(); content != nil {
+		return typesOf(content)
+	}
 	set.lock.RLock()
 	defer set.lock.RUnlock()
 
+	return typesOf(set.set)
+}
+
+func typesOf(values map[Type]float64) []Type {
 	var result sortedMetricTypes
-	for metricType := range set.set {
+	for metricType := range values {
 		result = append(result, metricType)
 	}
 	sort.Sort(result)
@@ -107,6 +158,7 @@ func (set *Set) Types() []Type {
 func (set *Set) Clear(metricType Type) {
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	delete(set.set, metricType)
 }
@@ -115,6 +167,7 @@ func (set *Set) Clear(metricType Type) {
 func (set *Set) ClearAll(clearInherited, clearNonInherited bool) {
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	var toRemove []Type
 	for metricType := range set.set {
@@ -128,13 +181,17 @@ func (set *Set) ClearAll(clearInherited, clearNonInherited bool) {
 }
 
 func copyContent(src *Set, lock bool) map[Type]float64 {
-	if lock {
-		src.lock.RLock()
-		defer src.lock.RUnlock()
+	content := src.frozenContent()
+	if content == nil {
+		if lock {
+			src.lock.RLock()
+			defer src.lock.RUnlock()
+		}
+		content = src.set
 	}
 
-	dst := make(map[Type]float64, len(src.set))
-	for metricType, pair := range src.set {
+	dst := make(map[Type]float64, len(content))
+	for metricType, pair := range content {
 		dst[metricType] = pair
 	}
 	return dst
@@ -146,6 +203,7 @@ func (set *Set) AddAll(other *Set) {
 
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	for metricType, value := range otherCopy {
 		old := set.set[metricType]
@@ -153,12 +211,26 @@ func (set *Set) AddAll(other *Set) {
 	}
 }
 
+// Snapshot returns an immutable copy of the metric types and their values currently in the set.
+func (set *Set) Snapshot() *Snapshot {
+	if content := set.frozenContent(); content != nil {
+		// The frozen content is never changed so it can be shared with the snapshot.
+		return &Snapshot{
+			set: content,
+		}
+	}
+	return &Snapshot{
+		set: copyContent(set, true),
+	}
+}
+
 // SetAll sets the values from another metric set in this set.
 func (set *Set) SetAll(other *Set) {
 	otherCopy := copyContent(other, true)
 
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	for metricType, value := range otherCopy {
 		set.set[metricType] = value
@@ -169,6 +241,7 @@ func (set *Set) SetAll(other *Set) {
 func (set *Set) Update() {
 	set.lock.Lock()
 	defer set.lock.Unlock()
+	set.thaw()
 
 	unmarked := make([]Type, 0, len(set.set))
 	for metricType := range set.set {
@@ -151,3 +151,31 @@ func TestSet_Update(t *testing.T) {
 
 	assert.Equal(t, 75.0, set.Get(CPUFree))
 }
+
+func TestSet_FreezeKeepsContentReadable(t *testing.T) {
+	set := NewSet()
+	set.Set(CPUTotal, 100.0)
+	assert.False(t, set.Frozen())
+
+	set.Freeze()
+
+	assert.True(t, set.Frozen())
+	assert.Equal(t, 1, set.Size())
+	assert.Equal(t, 100.0, set.Get(CPUTotal))
+	assert.Equal(t, []Type{CPUTotal}, set.Types())
+}
+
+func TestSet_ChangingAFrozenSetThawsItWithoutChangingSnapshots(t *testing.T) {
+	set := NewSet()
+	set.Set(CPUTotal, 100.0)
+	set.Set(CPUUsed, 25.0)
+	set.Set(CPUFree, 0.0)
+	set.Freeze()
+	snapshot := set.Snapshot()
+
+	set.Update()
+
+	assert.False(t, set.Frozen())
+	assert.Equal(t, 75.0, set.Get(CPUFree))
+	assert.Equal(t, 0.0, snapshot.Get(CPUFree))
+}
@@ -65,7 +65,14 @@ var (
 		Name:       "cpu_free",
 		Unit:       "%",
 		Inherited:  false,
-		derivation: computeFree(CPUTotal, CPUUsed),
+		derivation: computeFree(CPUTotal, CPUUsed, CPUOvercommit),
+	}
+	// CPUOvercommit represents the factor the total cpu of a group is multiplied with when computing the free cpu of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	CPUOvercommit = Type{
+		Name:      "cpu_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// MemoryTotal represents the total memory.
@@ -85,7 +92,14 @@ var (
 		Name:       "memory_free",
 		Unit:       "bytes",
 		Inherited:  false,
-		derivation: computeFree(MemoryTotal, MemoryUsed),
+		derivation: computeFree(MemoryTotal, MemoryUsed, MemoryOvercommit),
+	}
+	// MemoryOvercommit represents the factor the total memory of a group is multiplied with when computing the free memory of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	MemoryOvercommit = Type{
+		Name:      "memory_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// DiskTotal represents the total disk for a group
@@ -105,7 +119,14 @@ var (
 		Name:       "disk_free",
 		Unit:       "bytes",
 		Inherited:  false,
-		derivation: computeFree(DiskTotal, DiskUsed),
+		derivation: computeFree(DiskTotal, DiskUsed, DiskOvercommit),
+	}
+	// DiskOvercommit represents the factor the total disk of a group is multiplied with when computing the free disk of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	DiskOvercommit = Type{
+		Name:      "disk_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// NetworkTotal represents the total network for a group
@@ -125,7 +146,14 @@ var (
 		Name:       "network_free",
 		Unit:       "bits",
 		Inherited:  false,
-		derivation: computeFree(NetworkTotal, NetworkUsed),
+		derivation: computeFree(NetworkTotal, NetworkUsed, NetworkOvercommit),
+	}
+	// NetworkOvercommit represents the factor the total network of a group is multiplied with when computing the free network of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	NetworkOvercommit = Type{
+		Name:      "network_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// GPUTotal represents the total gpu for a group, each gpu adds 100 %, so a 24 core gpu will have 2400 % gpu.
@@ -145,7 +173,14 @@ var (
 		Name:       "gpu_free",
 		Unit:       "%",
 		Inherited:  false,
-		derivation: computeFree(GPUTotal, GPUUsed),
+		derivation: computeFree(GPUTotal, GPUUsed, GPUOvercommit),
+	}
+	// GPUOvercommit represents the factor the total gpu of a group is multiplied with when computing the free gpu of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	GPUOvercommit = Type{
+		Name:      "gpu_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// FileDescriptorsTotal represents the total number of file descriptors available for a group.
@@ -165,7 +200,14 @@ var (
 		Name:       "file_descriptors_free",
 		Unit:       "#",
 		Inherited:  false,
-		derivation: computeFree(FileDescriptorsTotal, FileDescriptorsUsed),
+		derivation: computeFree(FileDescriptorsTotal, FileDescriptorsUsed, FileDescriptorsOvercommit),
+	}
+	// FileDescriptorsOvercommit represents the factor the total file descriptors of a group is multiplied with when computing the free file descriptors of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	FileDescriptorsOvercommit = Type{
+		Name:      "file_descriptors_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 
 	// PortsTotal represents the total number of ports available for a group.
@@ -185,7 +227,14 @@ var (
 		Name:       "ports_free",
 		Unit:       "#",
 		Inherited:  false,
-		derivation: computeFree(PortsTotal, PortsUsed),
+		derivation: computeFree(PortsTotal, PortsUsed, PortsOvercommit),
+	}
+	// PortsOvercommit represents the factor the total ports of a group is multiplied with when computing the free ports of
+	// the group, e.g. a factor of 2 allows the group to be oversubscribed by 2x.
+	PortsOvercommit = Type{
+		Name:      "ports_overcommit",
+		Unit:      "ratio",
+		Inherited: false,
 	}
 )
 
@@ -213,6 +262,8 @@ const (
 type derivation struct {
 	dependencies []Type
 	calculation  func(metricType Type, metricSet *Set)
+	// used is the used metric type of a derivation computing a free metric type, else it is nil.
+	used *Type
 }
 
 func (derivation *derivation) Dependencies() []Type {
@@ -223,11 +274,19 @@ func (derivation *derivation) Calculate(metricType Type, metricSet *Set) {
 	derivation.calculation(metricType, metricSet)
 }
 
-func computeFree(total, used Type) Derivation {
+// computeFree creates a derivation that computes the free value as the total multiplied by the overcommit factor
+// minus the used value. An overcommit factor of zero, i.e. an overcommit factor that is not set, counts as a factor
+// of one.
+func computeFree(total, used, overcommit Type) Derivation {
 	return &derivation{
-		dependencies: []Type{total, used},
+		dependencies: []Type{total, used, overcommit},
+		used:         &used,
 		calculation: func(free Type, metricSet *Set) {
-			metricSet.Set(free, metricSet.Get(total)-metricSet.Get(used))
+			factor := metricSet.Get(overcommit)
+			if factor == 0.0 {
+				factor = 1.0
+			}
+			metricSet.Set(free, metricSet.Get(total)*factor-metricSet.Get(used))
 		},
 	}
 }
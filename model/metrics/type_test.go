@@ -92,3 +92,26 @@ func TestMetricType_Derivation(t *testing.T) {
 	assert.Equal(t, 9900.0, set.Get(FileDescriptorsFree))
 	assert.Equal(t, 998.0, set.Get(PortsFree))
 }
+
+func TestMetricType_Derivation_with_overcommit(t *testing.T) {
+	set := NewSet()
+	set.Set(CPUTotal, 1000.0)
+	set.Set(CPUUsed, 500.0)
+	set.Set(CPUOvercommit, 2.0)
+	set.Set(CPUFree, 0.0)
+
+	set.Set(MemoryTotal, 128.0*GiB)
+	set.Set(MemoryUsed, 64.0*GiB)
+	set.Set(MemoryOvercommit, 1.5)
+	set.Set(MemoryFree, 0.0)
+
+	set.Set(DiskTotal, 4.0*TiB)
+	set.Set(DiskUsed, 1.0*TiB)
+	set.Set(DiskFree, 0.0)
+
+	set.Update()
+
+	assert.Equal(t, 1500.0, set.Get(CPUFree))
+	assert.Equal(t, 128*GiB, set.Get(MemoryFree))
+	assert.Equal(t, 3*TiB, set.Get(DiskFree))
+}
@@ -47,3 +47,21 @@ func (custom *ConcatenateCustom) Tuple(group *placement.Group, scopeSet *placeme
 	}
 	return tuple
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *ConcatenateCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := explainAll(custom.SubExpressions, group, scopeSet, entity)
+	var components []string
+	for _, contribution := range contributions {
+		for i := range contribution.Tuple {
+			components = append(components, contribution.Component(i))
+		}
+	}
+	return &placement.Explanation{
+		Name:          "concatenate",
+		Tuple:         custom.Tuple(group, scopeSet, entity),
+		Components:    components,
+		Contributions: contributions,
+	}
+}
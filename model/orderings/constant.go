@@ -42,3 +42,9 @@ type ConstantCustom struct {
 func (custom *ConstantCustom) Tuple(group *placement.Group, scopeSet *placement.ScopeSet, entity *placement.Entity) []float64 {
 	return []float64{custom.Constant}
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *ConstantCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	return explainLeaf("constant", custom.Tuple(group, scopeSet, entity), "%v", custom.Constant)
+}
@@ -53,3 +53,10 @@ func (custom *InverseCustom) Tuple(group *placement.Group, scopeSet *placement.S
 	}
 	return tuple
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *InverseCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := []*placement.Explanation{placement.Explain(custom.SubExpression, group, scopeSet, entity)}
+	return explainEntries("inverse", "inverse", custom.Tuple(group, scopeSet, entity), contributions)
+}
@@ -48,3 +48,9 @@ func (custom *LabelCustom) Tuple(group *placement.Group, scopeSet *placement.Sco
 	occurrences := scopeSet.LabelScope(group, custom.Scope).Count(custom.Pattern)
 	return []float64{float64(occurrences)}
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *LabelCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	return explainLeaf("label", custom.Tuple(group, scopeSet, entity), "%v", scoped("label", custom.Scope, custom.Pattern))
+}
@@ -49,3 +49,10 @@ func (custom *MapCustom) Tuple(group *placement.Group, scopeSet *placement.Scope
 	}
 	return tuple
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *MapCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := []*placement.Explanation{placement.Explain(custom.SubExpression, group, scopeSet, entity)}
+	return explainEntries("map", "map", custom.Tuple(group, scopeSet, entity), contributions)
+}
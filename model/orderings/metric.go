@@ -51,3 +51,9 @@ func (custom *MetricCustom) Tuple(group *placement.Group, scopeSet *placement.Sc
 	}
 	return []float64{0.0}
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *MetricCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	return explainLeaf("metric", custom.Tuple(group, scopeSet, entity), "%v.%v", custom.Source, custom.MetricType.Name)
+}
@@ -69,3 +69,10 @@ func (custom *MultiplyCustom) Tuple(group *placement.Group, scopeSet *placement.
 	}
 	return result
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *MultiplyCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := explainAll(custom.SubExpressions, group, scopeSet, entity)
+	return explainEntries("multiply", "multiply", custom.Tuple(group, scopeSet, entity), contributions)
+}
@@ -47,3 +47,10 @@ func (custom *NegateCustom) Tuple(group *placement.Group, scopeSet *placement.Sc
 	}
 	return tuple
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *NegateCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := []*placement.Explanation{placement.Explain(custom.SubExpression, group, scopeSet, entity)}
+	return explainEntries("negate", "negate", custom.Tuple(group, scopeSet, entity), contributions)
+}
@@ -37,7 +37,7 @@ func Relation(scope, pattern *labels.Label) placement.Ordering {
 }
 
 // RelationCustom can create a tuple of one float which is the number of occurrences of relations that match the pattern
-// in the given scope.
+// in the given scope, where the occurrences of a relation is the sum of its weights.
 type RelationCustom struct {
 	Scope   *labels.Label
 	Pattern *labels.Label
@@ -45,6 +45,13 @@ type RelationCustom struct {
 
 // Tuple returns a tuple of floats created from the group, scope groups and the entity.
 func (custom *RelationCustom) Tuple(group *placement.Group, scopeSet *placement.ScopeSet, entity *placement.Entity) []float64 {
-	occurrences := scopeSet.RelationScope(group, custom.Scope).Count(custom.Pattern)
-	return []float64{float64(occurrences)}
+	occurrences := scopeSet.RelationScope(group, custom.Scope).Weight(custom.Pattern)
+	return []float64{occurrences}
+}
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *RelationCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	return explainLeaf("relation", custom.Tuple(group, scopeSet, entity), "%v",
+		scoped("relation", custom.Scope, custom.Pattern))
 }
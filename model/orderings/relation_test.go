@@ -41,3 +41,14 @@ func TestOrderByRelation(t *testing.T) {
 
 	assert.True(t, placement.Less(ordering.Tuple(group2, scopeSet, entity), ordering.Tuple(group1, scopeSet, entity)))
 }
+
+func TestOrderByRelation_sums_the_weights_of_the_relations(t *testing.T) {
+	relation := labels.NewLabel("schemaless", "instance", "mezzanine")
+	ordering := Relation(nil, relation)
+	group := placement.NewGroup("group")
+	group.Relations.AddWeighted(relation, 0.5)
+	group.Relations.AddWeighted(relation, 0.25)
+	scopeSet := placement.NewScopeSet([]*placement.Group{group})
+
+	assert.Equal(t, []float64{0.75}, ordering.Tuple(group, scopeSet, nil))
+}
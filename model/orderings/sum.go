@@ -69,3 +69,10 @@ func (custom *SumCustom) Tuple(group *placement.Group, scopeSet *placement.Scope
 	}
 	return result
 }
+
+// Explain returns the explanation of the tuple created from the group, scope groups and the entity.
+func (custom *SumCustom) Explain(group *placement.Group, scopeSet *placement.ScopeSet,
+	entity *placement.Entity) *placement.Explanation {
+	contributions := explainAll(custom.SubExpressions, group, scopeSet, entity)
+	return explainEntries("sum", "sum", custom.Tuple(group, scopeSet, entity), contributions)
+}
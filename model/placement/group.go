@@ -34,6 +34,9 @@ type Group struct {
 	Metrics   *metrics.Set
 	Relations *labels.Bag
 	Entities  Entities
+	// History is an optional bounded history of the utilisation of the group, the values of the statistics of the
+	// history are stored in the metrics of the group.
+	History *metrics.History
 }
 
 // NewGroup will create a new group with the given name.
@@ -47,7 +50,9 @@ func NewGroup(name string) *Group {
 	}
 }
 
-// Update will update the relations and metrics of the group from those of its entities.
+// Update will update the relations and metrics of the group from those of its entities. The labels, relations and
+// metrics of the group are frozen after the update, so they can be read lock-free during placement until they are
+// changed again.
 func (group *Group) Update() {
 	newRelations := labels.NewBag()
 	newMetrics := metrics.NewSet()
@@ -59,4 +64,16 @@ func (group *Group) Update() {
 	group.Metrics.ClearAll(true, false)
 	group.Metrics.SetAll(newMetrics)
 	group.Metrics.Update()
+	group.Labels.Freeze()
+	group.Relations.Freeze()
+	group.Metrics.Freeze()
+}
+
+// Record will record the current metrics of the group in the history of the group and update the metrics of the group
+// with the statistics of the history. If the group has no history nothing will happen.
+func (group *Group) Record() {
+	if group.History == nil {
+		return
+	}
+	group.History.Record(group.Metrics)
 }
@@ -87,3 +87,48 @@ func TestGroup_Update_remove_all_entities_will_give_group_zero_load(t *testing.T
 	assert.Equal(t, 128*metrics.GiB, group.Metrics.Get(metrics.MemoryFree))
 	assert.Equal(t, 0, group.Relations.Count(label))
 }
+
+func TestGroup_Record_updates_the_statistics_of_the_group(t *testing.T) {
+	group := NewGroup("some-group")
+	percentile := metrics.Percentile(metrics.CPUUsed, 95)
+	group.History = metrics.NewHistory(10, percentile)
+
+	for _, used := range []float64{100.0, 500.0, 200.0} {
+		entity := NewEntity("entity")
+		entity.Metrics.Set(metrics.CPUUsed, used)
+		group.Entities.Add(entity)
+		group.Update()
+		group.Record()
+	}
+
+	assert.Equal(t, []float64{100.0, 500.0, 200.0}, group.History.Samples(metrics.CPUUsed))
+	assert.Equal(t, 500.0, group.Metrics.Get(percentile.Type))
+}
+
+func TestGroup_Record_without_history_does_nothing(t *testing.T) {
+	group := NewGroup("some-group")
+	group.Metrics.Set(metrics.CPUUsed, 100.0)
+	group.Record()
+
+	assert.Equal(t, 1, group.Metrics.Size())
+}
+
+func TestGroup_Update_freezes_labels_relations_and_metrics(t *testing.T) {
+	group := NewGroup("some-group")
+	group.Labels.Add(labels.NewLabel("host", "some-host"))
+	group.Metrics.Set(metrics.MemoryTotal, 128*metrics.GiB)
+	entity := NewEntity("entity")
+	entity.Metrics.Add(metrics.MemoryUsed, 16*metrics.GiB)
+	group.Entities.Add(entity)
+
+	group.Update()
+
+	assert.True(t, group.Labels.Frozen())
+	assert.True(t, group.Relations.Frozen())
+	assert.True(t, group.Metrics.Frozen())
+
+	group.Metrics.Set(metrics.MemoryTotal, 64*metrics.GiB)
+
+	assert.False(t, group.Metrics.Frozen())
+	assert.Equal(t, 64*metrics.GiB, group.Metrics.Get(metrics.MemoryTotal))
+}
@@ -70,3 +70,14 @@ func (ordering name) Tuple(group *Group, scopeSet *ScopeSet, entity *Entity) []f
 	}
 	return result
 }
+
+// Explain returns the explanation of the tuple of the characters of the group name, where each component is named after
+// its position in the name.
+func (ordering name) Explain(group *Group, scopeSet *ScopeSet, entity *Entity) *Explanation {
+	tuple := ordering.Tuple(group, scopeSet, entity)
+	return &Explanation{
+		Name:       "name",
+		Tuple:      tuple,
+		Components: Components("name", len(tuple)),
+	}
+}
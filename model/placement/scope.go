@@ -24,42 +24,210 @@ package placement
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/svenskmand/mimir-lib/model/labels"
 )
 
 // NewScopeSet creates a new scope set for use in computations that need the label or relation scope of a group.
+// Labels and relations that have expired at the time of creation will not be part of any scope.
 func NewScopeSet(scopeGroups []*Group) *ScopeSet {
+	return NewScopeSetAt(scopeGroups, time.Now())
+}
+
+// NewScopeSetAt creates a new scope set like NewScopeSet where labels and relations that have expired at the given
+// time will not be part of any scope.
+func NewScopeSetAt(scopeGroups []*Group, now time.Time) *ScopeSet {
 	return &ScopeSet{
 		scopeGroups: scopeGroups,
 		cache:       map[string]*scopeResult{},
+		active:      map[*Group]*labels.Bag{},
+		now:         now,
+		generation:  atomic.AddUint64(&generations, 1),
 	}
 }
 
+// NewIndexedScopeSet creates a new scope set like NewScopeSetAt where the scopes of the given scope patterns, e.g.
+// rack.* and datacenter.*, are pre-computed once for all scope groups. Looking up the scope of a group for one of the
+// scope patterns is lock-free, which avoids contention between concurrent workers of a placement round, while other
+// scopes are computed lazily as for any other scope set. The pre-computed scopes are updated incrementally by Update.
+func NewIndexedScopeSet(scopeGroups []*Group, now time.Time, scopes ...*labels.Label) *ScopeSet {
+	set := NewScopeSetAt(scopeGroups, now)
+	set.index.Store(newScopeIndex(scopes).update(scopeGroups, scopeGroups, now))
+	return set
+}
+
 // ScopeSet contains a set of pre-computed label or relation bags for a given group and scope. The methods label scope
 // and relation scope will return the labels or relations in the scope if they are pre-computed, else they will be
 // computed and stored for the next call.
 type ScopeSet struct {
 	scopeGroups []*Group
 	cache       map[string]*scopeResult
-	lock        sync.Mutex
+	// active maps a scope group to its labels which have not expired, it is filled lazily when scopes are computed.
+	active     map[*Group]*labels.Bag
+	now        time.Time
+	topology   *Topology
+	generation uint64
+	// index holds the *scopeIndex of the pre-computed scopes of an indexed scope set, the index is never changed but
+	// replaced when the scope set is updated.
+	index atomic.Value
+	lock  sync.Mutex
 }
 
 type scopeResult struct {
 	labels    *labels.Bag
 	relations *labels.Bag
 	groups    []*Group
+	// used is set to 1 when the result has been found in the index of the scope set.
+	used int32
+}
+
+// newScopeResult computes the scope of the given groups, the bags of a scope are never changed after they have been
+// computed.
+func newScopeResult(groups []*Group, now time.Time) *scopeResult {
+	labelsResult := labels.NewBag()
+	relationsResult := labels.NewBag()
+	for _, group := range groups {
+		labelsResult.AddAll(group.Labels.Active(now))
+		relationsResult.AddAll(group.Relations.Active(now))
+	}
+	labelsResult.Freeze()
+	relationsResult.Freeze()
+	return &scopeResult{
+		groups:    groups,
+		labels:    labelsResult,
+		relations: relationsResult,
+	}
+}
+
+// scopeIndex is an immutable index of the pre-computed scopes of an indexed scope set.
+type scopeIndex struct {
+	patterns []*labels.Label
+	// scopes maps a scope pattern to a map from the scope labels matching the pattern to their scope.
+	scopes map[string]map[string]*scopeResult
+	// keys maps a scope pattern to a map from group names to the scope labels of the group matching the pattern.
+	keys  map[string]map[string][]string
+	empty *scopeResult
+}
+
+func newScopeIndex(patterns []*labels.Label) *scopeIndex {
+	index := &scopeIndex{
+		patterns: patterns,
+		scopes:   map[string]map[string]*scopeResult{},
+		keys:     map[string]map[string][]string{},
+		empty:    newScopeResult(nil, time.Time{}),
+	}
+	for _, pattern := range patterns {
+		index.scopes[pattern.String()] = map[string]*scopeResult{}
+		index.keys[pattern.String()] = map[string][]string{}
+	}
+	return index
+}
+
+// update returns a new index where the scopes of the updated groups have been recomputed, the scope groups must
+// contain the updated groups.
+func (index *scopeIndex) update(scopeGroups, updated []*Group, now time.Time) *scopeIndex {
+	result := &scopeIndex{
+		patterns: index.patterns,
+		scopes:   make(map[string]map[string]*scopeResult, len(index.scopes)),
+		keys:     make(map[string]map[string][]string, len(index.keys)),
+		empty:    index.empty,
+	}
+	for _, pattern := range index.patterns {
+		scopes := make(map[string]*scopeResult, len(index.scopes[pattern.String()]))
+		for key, scope := range index.scopes[pattern.String()] {
+			scopes[key] = scope
+		}
+		keys := make(map[string][]string, len(index.keys[pattern.String()]))
+		for name, groupKeys := range index.keys[pattern.String()] {
+			keys[name] = groupKeys
+		}
+
+		affected := map[string]bool{}
+		for _, group := range updated {
+			for _, key := range keys[group.Name] {
+				affected[key] = true
+			}
+			var groupKeys []string
+			for _, label := range group.Labels.Active(now).Find(pattern) {
+				groupKeys = append(groupKeys, label.String())
+				affected[label.String()] = true
+			}
+			keys[group.Name] = groupKeys
+		}
+
+		members := map[string][]*Group{}
+		for _, scopeGroup := range scopeGroups {
+			for _, key := range keys[scopeGroup.Name] {
+				if affected[key] {
+					members[key] = append(members[key], scopeGroup)
+				}
+			}
+		}
+		for key := range affected {
+			if len(members[key]) == 0 {
+				delete(scopes, key)
+				continue
+			}
+			scopes[key] = newScopeResult(members[key], now)
+		}
+
+		result.scopes[pattern.String()] = scopes
+		result.keys[pattern.String()] = keys
+	}
+	return result
+}
+
+func (set *ScopeSet) indexed() *scopeIndex {
+	index, _ := set.index.Load().(*scopeIndex)
+	return index
+}
+
+// lookup finds the scope of the group in the index of the scope set without locking, it returns nil if the scope
+// pattern is not indexed or the group has more than one label matching the scope pattern.
+func (set *ScopeSet) lookup(group *Group, scope *labels.Label) *scopeResult {
+	index := set.indexed()
+	if index == nil {
+		return nil
+	}
+	scopes, exists := index.scopes[scope.String()]
+	if !exists {
+		return nil
+	}
+	groupLabels := group.Labels.Active(set.now).Find(scope)
+	switch len(groupLabels) {
+	case 0:
+		return index.empty
+	case 1:
+		result, exists := scopes[groupLabels[0].String()]
+		if !exists {
+			return index.empty
+		}
+		if atomic.LoadInt32(&result.used) == 0 {
+			atomic.StoreInt32(&result.used, 1)
+		}
+		return result
+	}
+	return nil
 }
 
 func (set *ScopeSet) scope(group *Group, scope *labels.Label) *scopeResult {
 	if scope == nil {
 		return &scopeResult{
 			groups:    []*Group{group},
-			labels:    group.Labels,
-			relations: group.Relations,
+			labels:    group.Labels.Active(set.now),
+			relations: group.Relations.Active(set.now),
 		}
 	}
-	groupLabels := group.Labels.Find(scope)
+	if result := set.lookup(group, scope); result != nil {
+		return result
+	}
+
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	groupLabels := group.Labels.Active(set.now).Find(scope)
 	for _, label := range groupLabels {
 		if result, exists := set.cache[label.String()]; exists {
 			return result
@@ -67,24 +235,17 @@ func (set *ScopeSet) scope(group *Group, scope *labels.Label) *scopeResult {
 	}
 
 	var groupsResult []*Group
-	labelsResult := labels.NewBag()
-	relationsResult := labels.NewBag()
 	for _, scopeGroup := range set.scopeGroups {
+		scopeGroupLabels := set.activeLabels(scopeGroup)
 		for _, scopeLabel := range groupLabels {
-			if scopeGroup.Labels.Contains(scopeLabel) {
+			if scopeGroupLabels.Contains(scopeLabel) {
 				groupsResult = append(groupsResult, scopeGroup)
-				labelsResult.AddAll(scopeGroup.Labels)
-				relationsResult.AddAll(scopeGroup.Relations)
 				break
 			}
 		}
 	}
 
-	result := &scopeResult{
-		groups:    groupsResult,
-		labels:    labelsResult,
-		relations: relationsResult,
-	}
+	result := newScopeResult(groupsResult, set.now)
 	for _, label := range groupLabels {
 		set.cache[label.String()] = result
 	}
@@ -92,6 +253,104 @@ func (set *ScopeSet) scope(group *Group, scope *labels.Label) *scopeResult {
 	return result
 }
 
+// activeLabels returns the labels of the scope group which have not expired, they are computed once for each scope
+// group until the group is updated. The lock of the scope set must be held.
+func (set *ScopeSet) activeLabels(scopeGroup *Group) *labels.Bag {
+	active, exists := set.active[scopeGroup]
+	if !exists {
+		active = scopeGroup.Labels.Active(set.now)
+		set.active[scopeGroup] = active
+	}
+	return active
+}
+
+// Update must be called when the labels or relations of the given groups have changed, e.g. after calling
+// Group.Update, otherwise the scopes of the scope set will be stale. It removes the lazily computed scopes which contain
+// the groups or are computed for the labels of the groups, and it recomputes the pre-computed scopes of an indexed
+// scope set which contain the groups. Groups that are not part of the scope groups are ignored.
+func (set *ScopeSet) Update(groups ...*Group) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	known := make(map[*Group]bool, len(set.scopeGroups))
+	for _, scopeGroup := range set.scopeGroups {
+		known[scopeGroup] = true
+	}
+	var updated []*Group
+	for _, group := range groups {
+		if known[group] {
+			updated = append(updated, group)
+		}
+	}
+	if len(updated) == 0 {
+		return
+	}
+
+	for key, result := range set.cache {
+		for _, group := range result.groups {
+			if contains(updated, group) {
+				delete(set.cache, key)
+				break
+			}
+		}
+	}
+	for _, group := range updated {
+		for _, label := range group.Labels.Active(set.now).Labels() {
+			delete(set.cache, label.String())
+		}
+		delete(set.active, group)
+	}
+
+	if index := set.indexed(); index != nil {
+		set.index.Store(index.update(set.scopeGroups, updated, set.now))
+	}
+	set.generation = atomic.AddUint64(&generations, 1)
+}
+
+func contains(groups []*Group, group *Group) bool {
+	for _, candidate := range groups {
+		if candidate == group {
+			return true
+		}
+	}
+	return false
+}
+
+// generations is the last generation handed out to a scope set.
+var generations uint64
+
+// Generation returns an identifier of the state of the scope set which changes every time the scope set is updated with
+// one of its scope groups. A copy has the same generation as the original until either of them is updated, so
+// computations over all the scope groups can be cached by generation, e.g. in concurrent workers of a placement round.
+func (set *ScopeSet) Generation() uint64 {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	return set.generation
+}
+
+// Now returns the time at which the scope set decides if labels and relations have expired.
+func (set *ScopeSet) Now() time.Time {
+	return set.now
+}
+
+// SetTopology sets the topology of the groups which can be used by requirements and orderings that depend on the
+// hierarchy of the groups, e.g. that two groups are in different racks but in the same datacenter.
+func (set *ScopeSet) SetTopology(topology *Topology) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	set.topology = topology
+}
+
+// Topology returns the topology of the groups or nil if the scope set has no topology.
+func (set *ScopeSet) Topology() *Topology {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	return set.topology
+}
+
 // ScopeGroups returns the set of groups, given to a scope set when it is created, which are used as the basis for all
 // scope calculations.
 func (set *ScopeSet) ScopeGroups() []*Group {
@@ -110,24 +369,30 @@ func (set *ScopeSet) CompleteScope() map[string]*Group {
 			groups[group.Name] = group
 		}
 	}
+	if index := set.indexed(); index != nil {
+		for _, scopes := range index.scopes {
+			for _, result := range scopes {
+				if atomic.LoadInt32(&result.used) == 0 {
+					continue
+				}
+				for _, group := range result.groups {
+					groups[group.Name] = group
+				}
+			}
+		}
+	}
 
 	return groups
 }
 
 // LabelScope finds all labels in scope of the given group and caches them for the next call.
 func (set *ScopeSet) LabelScope(group *Group, scope *labels.Label) *labels.Bag {
-	set.lock.Lock()
-	defer set.lock.Unlock()
-
 	result := set.scope(group, scope)
 	return result.labels
 }
 
 // RelationScope finds all relations in scope of the given group and caches them for the next call.
 func (set *ScopeSet) RelationScope(group *Group, scope *labels.Label) *labels.Bag {
-	set.lock.Lock()
-	defer set.lock.Unlock()
-
 	result := set.scope(group, scope)
 	return result.relations
 }
@@ -137,9 +402,17 @@ func (set *ScopeSet) Copy() *ScopeSet {
 	set.lock.Lock()
 	defer set.lock.Unlock()
 
-	result := NewScopeSet(set.scopeGroups)
+	result := NewScopeSetAt(set.scopeGroups, set.now)
+	result.topology = set.topology
+	result.generation = set.generation
+	if index := set.indexed(); index != nil {
+		result.index.Store(index)
+	}
 	for key, value := range set.cache {
 		result.cache[key] = value
 	}
+	for group, active := range set.active {
+		result.active[group] = active
+	}
 	return result
 }
@@ -24,6 +24,7 @@ package placement_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/svenskmand/mimir-lib/model/labels"
 	"github.com/svenskmand/mimir-lib/model/orderings"
@@ -154,6 +155,25 @@ func TestScopeSet_LabelScope_LabelsWithNoScope(t *testing.T) {
 	assert.Equal(t, 1, scopeLabels.Count(labels.NewLabel("host", "host42-dc1")))
 }
 
+func TestScopeSet_LabelScope_IgnoresExpiredLabels(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	issue := labels.NewLabel("issues", "someissue")
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	group2.Labels.SetExpiry(issue, now.Add(time.Minute))
+	scopeGroups := []*placement.Group{group1, group2}
+
+	scope := labels.NewLabel("rack", "*")
+	before := placement.NewScopeSetAt(scopeGroups, now)
+	assert.Equal(t, 1, before.LabelScope(group1, scope).Count(issue))
+	assert.Equal(t, 1, before.LabelScope(group2, nil).Count(issue))
+
+	after := placement.NewScopeSetAt(scopeGroups, now.Add(time.Minute))
+	assert.Equal(t, 0, after.LabelScope(group1, scope).Count(issue))
+	assert.Equal(t, 0, after.LabelScope(group2, nil).Count(issue))
+	assert.Equal(t, now.Add(time.Minute), after.Copy().Now())
+}
+
 func TestScopeSet_RelationScope_RelationsWithScope(t *testing.T) {
 	group1 := hostWithoutIssue()
 	group2 := hostWithoutIssue()
@@ -209,3 +229,173 @@ func TestScopeSet_Copy(t *testing.T) {
 	assert.True(t, scopeRelations1 == scopeRelations2)
 	assert.False(t, scopeSet1 == scopeSet2)
 }
+
+func TestNewIndexedScopeSet_LabelScope_and_RelationScope_use_the_index(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	group3 := topologyHost("dc1", "dc1-a008", "host44-dc1")
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2, group3}, time.Now(), scope)
+
+	scopeLabels := scopeSet.LabelScope(group1, scope)
+	assert.Equal(t, 2, scopeLabels.Count(labels.NewLabel("rack", "dc1-a007")))
+	assert.Equal(t, 0, scopeLabels.Count(labels.NewLabel("rack", "dc1-a008")))
+	assert.True(t, scopeLabels == scopeSet.LabelScope(group2, scope))
+	assert.Equal(t, 1, scopeSet.RelationScope(group2, scope).Count(labels.NewLabel("redis", "instance", "store1")))
+	assert.Equal(t, 0, scopeSet.RelationScope(group3, scope).Size())
+
+	scopeGroups := scopeSet.CompleteScope()
+	assert.Equal(t, 3, len(scopeGroups))
+}
+
+func TestNewIndexedScopeSet_CompleteScope_only_contains_used_scopes(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := topologyHost("dc1", "dc1-a008", "host44-dc1")
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2}, time.Now(), scope)
+
+	scopeSet.LabelScope(group1, scope)
+
+	scopeGroups := scopeSet.CompleteScope()
+	assert.Equal(t, 1, len(scopeGroups))
+	assert.NotNil(t, scopeGroups["host-without-issue"])
+}
+
+func TestScopeSet_Update_recomputes_the_scopes_of_the_updated_groups(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scope := labels.NewLabel("rack", "*")
+	relation := labels.NewLabel("redis", "instance", "store1")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2}, time.Now(), scope)
+	before := scopeSet.RelationScope(group1, scope)
+	assert.Equal(t, 1, before.Count(relation))
+
+	entity := placement.NewEntity("entity")
+	entity.Relations.Add(relation)
+	group2.Entities.Add(entity)
+	group2.Update()
+	scopeSet.Update(group2)
+
+	assert.Equal(t, 2, scopeSet.RelationScope(group2, scope).Count(relation))
+	assert.Equal(t, 1, before.Count(relation))
+}
+
+func TestScopeSet_Update_ignores_groups_that_are_not_scope_groups(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1}, time.Now(), scope)
+
+	scopeSet.Update(group2)
+
+	assert.Equal(t, 1, len(scopeSet.ScopeGroups()))
+	assert.Equal(t, 1, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+}
+
+func TestScopeSet_Update_removes_stale_lazy_scopes(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scope := labels.NewLabel("rack", "*")
+	relation := labels.NewLabel("redis", "instance", "store1")
+	scopeSet := placement.NewScopeSet([]*placement.Group{group1, group2})
+	assert.Equal(t, 1, scopeSet.RelationScope(group1, scope).Count(relation))
+
+	entity := placement.NewEntity("entity")
+	entity.Relations.Add(relation)
+	group2.Entities.Add(entity)
+	group2.Update()
+	assert.Equal(t, 1, scopeSet.RelationScope(group1, scope).Count(relation))
+
+	scopeSet.Update(group2)
+
+	assert.Equal(t, 2, scopeSet.RelationScope(group1, scope).Count(relation))
+}
+
+func TestScopeSet_Update_moves_a_group_whose_labels_changed(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2}, time.Now(), scope)
+	assert.Equal(t, 2, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+
+	group2.Labels = labels.NewBag()
+	group2.Labels.Add(labels.NewLabel("datacenter", "dc1"))
+	group2.Labels.Add(labels.NewLabel("rack", "dc1-a008"))
+	scopeSet.Update(group2)
+
+	assert.Equal(t, 1, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+	assert.Equal(t, 1, scopeSet.LabelScope(group2, scope).Count(labels.NewLabel("rack", "dc1-a008")))
+}
+
+func TestScopeSet_Update_recomputes_the_active_labels_of_a_group_whose_labels_changed(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	issue := labels.NewLabel("issues", "someissue")
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	group2.Labels.SetExpiry(issue, now)
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewScopeSetAt([]*placement.Group{group1, group2}, now)
+	assert.Equal(t, 2, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+
+	group2.Labels = labels.NewBag()
+	group2.Labels.Add(labels.NewLabel("datacenter", "dc1"))
+	group2.Labels.Add(labels.NewLabel("rack", "dc1-a008"))
+	scopeSet.Update(group2)
+
+	assert.Equal(t, 1, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+	assert.Equal(t, 1, scopeSet.Copy().LabelScope(group2, scope).Count(labels.NewLabel("rack", "dc1-a008")))
+}
+
+func TestNewIndexedScopeSet_falls_back_to_lazy_scopes(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2}, time.Now(),
+		labels.NewLabel("rack", "*"))
+
+	scopeLabels := scopeSet.LabelScope(group1, labels.NewLabel("datacenter", "*"))
+	assert.Equal(t, 2, scopeLabels.Count(labels.NewLabel("datacenter", "dc1")))
+	scopeLabels = scopeSet.LabelScope(group1, labels.NewLabel("*", "*"))
+	assert.Equal(t, 5, scopeLabels.Size())
+}
+
+func TestNewIndexedScopeSet_concurrent_lookups_and_updates(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scope := labels.NewLabel("rack", "*")
+	scopeSet := placement.NewIndexedScopeSet([]*placement.Group{group1, group2}, time.Now(), scope)
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				scopeSet.Copy().RelationScope(group1, scope)
+				scopeSet.LabelScope(group2, scope)
+			}
+			done <- struct{}{}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		scopeSet.Update(group1)
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	assert.Equal(t, 2, scopeSet.LabelScope(group1, scope).Count(labels.NewLabel("datacenter", "dc1")))
+}
+
+func TestScopeSet_Generation_changes_when_the_scope_set_is_updated(t *testing.T) {
+	group1 := hostWithoutIssue()
+	group2 := hostWithIssue()
+	scopeSet1 := placement.NewScopeSet([]*placement.Group{group1})
+	scopeSet2 := scopeSet1.Copy()
+	assert.Equal(t, scopeSet1.Generation(), scopeSet2.Generation())
+	assert.NotEqual(t, scopeSet1.Generation(), placement.NewScopeSet([]*placement.Group{group1}).Generation())
+
+	generation := scopeSet1.Generation()
+	scopeSet1.Update(group2)
+	assert.Equal(t, generation, scopeSet1.Generation())
+
+	scopeSet1.Update(group1)
+	assert.NotEqual(t, generation, scopeSet1.Generation())
+	assert.Equal(t, generation, scopeSet2.Generation())
+}
@@ -28,11 +28,15 @@ import (
 )
 
 // Transcript represents a transcript of which requirements passed and failed when evaluating groups for an entity.
+// Groups for which a requirement could not be evaluated, e.g. because it has an unknown comparison, are counted as
+// errored instead of failed, and the errors are counted by their message.
 type Transcript struct {
-	Requirement  string
-	GroupsPassed int
-	GroupsFailed int
-	Subscripts   map[Transcriptable]*Transcript
+	Requirement   string
+	GroupsPassed  int
+	GroupsFailed  int
+	GroupsErrored int
+	Errors        map[string]int
+	Subscripts    map[Transcriptable]*Transcript
 }
 
 // NewTranscript creates a new transcript with a description.
@@ -59,6 +63,18 @@ func (transcript *Transcript) IncFailed() {
 	transcript.GroupsFailed++
 }
 
+// IncErrored will increment the number of groups for which the requirements could not be evaluated due to the error.
+func (transcript *Transcript) IncErrored(err error) {
+	if transcript == nil {
+		return
+	}
+	transcript.GroupsErrored++
+	if transcript.Errors == nil {
+		transcript.Errors = map[string]int{}
+	}
+	transcript.Errors[err.Error()]++
+}
+
 // Subscript will create a sub transcript with the given description if one does not exist.
 func (transcript *Transcript) Subscript(transcriptable Transcriptable) *Transcript {
 	if transcript == nil {
@@ -86,11 +102,20 @@ func (transcript *Transcript) Copy() *Transcript {
 	for transcriptable, subscript := range transcript.Subscripts {
 		subscripts[transcriptable] = subscript.Copy()
 	}
+	var errors map[string]int
+	if transcript.Errors != nil {
+		errors = make(map[string]int, len(transcript.Errors))
+		for message, count := range transcript.Errors {
+			errors[message] = count
+		}
+	}
 	return &Transcript{
-		Requirement:  transcript.Requirement,
-		GroupsPassed: transcript.GroupsPassed,
-		GroupsFailed: transcript.GroupsFailed,
-		Subscripts:   subscripts,
+		Requirement:   transcript.Requirement,
+		GroupsPassed:  transcript.GroupsPassed,
+		GroupsFailed:  transcript.GroupsFailed,
+		GroupsErrored: transcript.GroupsErrored,
+		Errors:        errors,
+		Subscripts:    subscripts,
 	}
 }
 
@@ -102,6 +127,13 @@ func (transcript *Transcript) Add(other *Transcript) {
 	if transcript.Requirement == other.Requirement {
 		transcript.GroupsPassed += other.GroupsPassed
 		transcript.GroupsFailed += other.GroupsFailed
+		transcript.GroupsErrored += other.GroupsErrored
+		for message, count := range other.Errors {
+			if transcript.Errors == nil {
+				transcript.Errors = map[string]int{}
+			}
+			transcript.Errors[message] += count
+		}
 	}
 	for transcriptable, subScriptToAdd := range other.Subscripts {
 		subScript := transcript.Subscript(transcriptable)
@@ -111,8 +143,12 @@ func (transcript *Transcript) Add(other *Transcript) {
 
 func (transcript *Transcript) string(indent int) string {
 	space := strings.Repeat(" ", indent)
-	result := fmt.Sprintf("%v%v passed %v times and failed %v times\n",
+	result := fmt.Sprintf("%v%v passed %v times and failed %v times",
 		space, transcript.Requirement, transcript.GroupsPassed, transcript.GroupsFailed)
+	if transcript.GroupsErrored > 0 {
+		result += fmt.Sprintf(" and errored %v times", transcript.GroupsErrored)
+	}
+	result += "\n"
 	for _, subTranscript := range transcript.Subscripts {
 		result += subTranscript.string(indent + 1)
 	}
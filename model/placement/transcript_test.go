@@ -23,6 +23,7 @@
 package placement
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,3 +155,37 @@ func TestTranscript_Add_adds_other_transcript_to_this_transcript(t *testing.T) {
 		assert.Equal(t, len(subscript.Subscripts), len(copySubscript.Subscripts))
 	}
 }
+
+func TestTranscript_IncErrored_on_nil_transcript(t *testing.T) {
+	var transcript *Transcript
+	transcript.IncErrored(errors.New("error"))
+}
+
+func TestTranscript_IncErrored_counts_errors_separately_from_failures(t *testing.T) {
+	transcript := NewTranscript("transcript")
+	transcript.IncErrored(errors.New("error1"))
+	transcript.IncErrored(errors.New("error1"))
+	transcript.IncErrored(errors.New("error2"))
+
+	assert.Equal(t, 0, transcript.GroupsFailed)
+	assert.Equal(t, 3, transcript.GroupsErrored)
+	assert.Equal(t, map[string]int{"error1": 2, "error2": 1}, transcript.Errors)
+	assert.Contains(t, transcript.String(), "transcript passed 0 times and failed 0 times and errored 3 times")
+}
+
+func TestTranscript_Copy_and_Add_include_errors(t *testing.T) {
+	transcript := NewTranscript("transcript")
+	transcript.Subscript(&mockRequirement{}).IncErrored(errors.New("error"))
+
+	copy := transcript.Copy()
+	copy.Add(transcript)
+
+	for _, subscript := range copy.Subscripts {
+		assert.Equal(t, 2, subscript.GroupsErrored)
+		assert.Equal(t, map[string]int{"error": 2}, subscript.Errors)
+	}
+	for _, subscript := range transcript.Subscripts {
+		assert.Equal(t, 1, subscript.GroupsErrored)
+		assert.Equal(t, map[string]int{"error": 1}, subscript.Errors)
+	}
+}
@@ -66,6 +66,11 @@ func (requirement *AndRequirement) Passed(group *placement.Group, scopeSet *plac
 	return result
 }
 
+// Validate returns the first error of the sub-requirements that can not be evaluated.
+func (requirement *AndRequirement) Validate() error {
+	return validate(requirement.Requirements)
+}
+
 func (requirement *AndRequirement) String() string {
 	subRequirements := make([]string, 0, len(requirement.Requirements))
 	for _, subRequirement := range requirement.Requirements {
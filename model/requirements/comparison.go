@@ -22,7 +22,12 @@
 
 package requirements
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+
+	"github.com/svenskmand/mimir-lib/model/orderings"
+)
 
 // Comparison represents a condition on two numbers.
 type Comparison string
@@ -37,15 +42,54 @@ const (
 	// Equal requires the occurrences of a label to be equal to (=) the required occurrences.
 	Equal Comparison = "equal"
 
+	// NotEqual requires the occurrences of a label to be different from (!=) the required occurrences.
+	NotEqual Comparison = "not_equal"
+
 	// GreaterThanEqual requires the occurrences of a label to be greater than or equal to (>=) the required occurrences.
 	GreaterThanEqual Comparison = "greater_than_equal"
 
 	// GreaterThan requires the occurrences of a label to be greater than (>) the required occurrences.
 	GreaterThan Comparison = "greater_than"
+
+	// Between requires the occurrences of a label to be inside the range of the bounds, the required occurrences are
+	// not used.
+	Between Comparison = "between"
+
+	// ApproximatelyEqual requires the occurrences of a label to differ at most the tolerance of the bounds from the
+	// required occurrences, which makes it a robust replacement of Equal for metrics.
+	ApproximatelyEqual Comparison = "approximately_equal"
 )
 
-// Compare evaluates how the value a compares to the value b given the type of the comparison.
+// Bounds holds the extra arguments of the comparisons which need more than a single value to compare with.
+type Bounds struct {
+	// Range is the range of the Between comparison, the value of the bucket is not used.
+	Range *orderings.Bucket
+	// Tolerance is the largest difference between two values that are approximately equal.
+	Tolerance float64
+}
+
+// NewRange creates bounds for the Between comparison with a range from the start to the end endpoint.
+func NewRange(start, end *orderings.Endpoint) *Bounds {
+	return &Bounds{
+		Range: orderings.NewBucket(start, end, 0),
+	}
+}
+
+// NewTolerance creates bounds for the ApproximatelyEqual comparison with the given tolerance.
+func NewTolerance(tolerance float64) *Bounds {
+	return &Bounds{
+		Tolerance: tolerance,
+	}
+}
+
+// Compare evaluates how the value a compares to the value b given the type of the comparison. Comparisons which need
+// bounds will return an error, they must be evaluated with CompareBounded.
 func (comparison Comparison) Compare(a, b float64) (bool, error) {
+	return comparison.CompareBounded(a, b, nil)
+}
+
+// CompareBounded evaluates how the value a compares to the value b, or to the bounds, given the type of the comparison.
+func (comparison Comparison) CompareBounded(a, b float64, bounds *Bounds) (bool, error) {
 	switch comparison {
 	case LessThan:
 		return a < b, nil
@@ -53,10 +97,80 @@ func (comparison Comparison) Compare(a, b float64) (bool, error) {
 		return a <= b, nil
 	case Equal:
 		return a == b, nil
+	case NotEqual:
+		return a != b, nil
 	case GreaterThanEqual:
 		return a >= b, nil
 	case GreaterThan:
 		return a > b, nil
+	case Between, ApproximatelyEqual:
+		if err := comparison.ValidateBounds(bounds); err != nil {
+			return false, err
+		}
+		if comparison == Between {
+			return bounds.Range.Contains(a), nil
+		}
+		return math.Abs(a-b) <= bounds.Tolerance, nil
 	}
-	return false, fmt.Errorf("unknown %T '%v'", comparison, string(comparison))
+	return false, &UnknownComparisonError{
+		Comparison: comparison,
+	}
+}
+
+// Validate returns an UnknownComparisonError iff the comparison is not one of the known comparisons.
+func (comparison Comparison) Validate() error {
+	switch comparison {
+	case LessThan, LessThanEqual, Equal, NotEqual, GreaterThanEqual, GreaterThan, Between, ApproximatelyEqual:
+		return nil
+	}
+	return &UnknownComparisonError{
+		Comparison: comparison,
+	}
+}
+
+// ValidateBounds returns an error if the comparison is unknown or if it can not be evaluated with the given bounds,
+// i.e. if the comparison is Between and the bounds have no valid range, or if the comparison is ApproximatelyEqual and
+// the bounds have no valid tolerance.
+func (comparison Comparison) ValidateBounds(bounds *Bounds) error {
+	if err := comparison.Validate(); err != nil {
+		return err
+	}
+	switch comparison {
+	case Between:
+		if bounds == nil || bounds.Range == nil {
+			return fmt.Errorf("the comparison %v needs a range", comparison)
+		}
+		if err := bounds.Range.Validate(); err != nil {
+			return fmt.Errorf("the range %v of the comparison %v is invalid: %v", bounds.Range, comparison, err)
+		}
+	case ApproximatelyEqual:
+		if bounds == nil {
+			return fmt.Errorf("the comparison %v needs a tolerance", comparison)
+		}
+		if !(bounds.Tolerance >= 0) {
+			return fmt.Errorf("the tolerance %v of the comparison %v is negative", bounds.Tolerance, comparison)
+		}
+	}
+	return nil
+}
+
+// describe returns a description of the condition that the comparison with the value and the bounds puts on a value.
+func describe(comparison Comparison, value interface{}, bounds *Bounds) string {
+	switch {
+	case comparison == Between && bounds != nil && bounds.Range != nil:
+		return fmt.Sprintf("%v %v", comparison, bounds.Range)
+	case comparison == ApproximatelyEqual && bounds != nil:
+		return fmt.Sprintf("%v %v within %v", comparison, value, bounds.Tolerance)
+	}
+	return fmt.Sprintf("%v %v", comparison, value)
+}
+
+// UnknownComparisonError is the error returned when comparing with a comparison that is not one of the known
+// comparisons, e.g. because of a typo in a configuration.
+type UnknownComparisonError struct {
+	Comparison Comparison
+}
+
+func (err *UnknownComparisonError) Error() string {
+	return fmt.Sprintf("unknown %T '%v'", err.Comparison, string(err.Comparison))
 }
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/svenskmand/mimir-lib/model/orderings"
 )
 
 func TestComparison_Compare_0_LessThan_1(t *testing.T) {
@@ -92,3 +93,62 @@ func TestComparison_Compare_Invalid_Comparison_Value(t *testing.T) {
 	_, err := Comparison("invalid").Compare(1, 0)
 	assert.NotNil(t, err)
 }
+
+func TestComparison_Validate(t *testing.T) {
+	for _, comparison := range []Comparison{LessThan, LessThanEqual, Equal, GreaterThanEqual, GreaterThan} {
+		assert.NoError(t, comparison.Validate())
+	}
+
+	err := Comparison("greater_then").Validate()
+	assert.EqualError(t, err, "unknown requirements.Comparison 'greater_then'")
+	assert.Equal(t, &UnknownComparisonError{Comparison: "greater_then"}, err)
+}
+
+func TestComparison_Compare_NotEqual(t *testing.T) {
+	result, err := NotEqual.Compare(0, 1)
+	assert.NoError(t, err)
+	assert.True(t, result)
+	result, err = NotEqual.Compare(1, 1)
+	assert.NoError(t, err)
+	assert.False(t, result)
+}
+
+func TestComparison_CompareBounded_Between(t *testing.T) {
+	bounds := NewRange(orderings.NewEndpoint(1, false), orderings.NewEndpoint(3, true))
+
+	for value, expected := range map[float64]bool{0.5: false, 1: true, 2: true, 3: false} {
+		result, err := Between.CompareBounded(value, 0, bounds)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, "%v", value)
+	}
+}
+
+func TestComparison_CompareBounded_ApproximatelyEqual(t *testing.T) {
+	bounds := NewTolerance(0.25)
+
+	for value, expected := range map[float64]bool{0.7: false, 0.75: true, 1.2: true, 1.3: false} {
+		result, err := ApproximatelyEqual.CompareBounded(value, 1, bounds)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, "%v", value)
+	}
+}
+
+func TestComparison_Compare_fails_for_comparisons_needing_bounds(t *testing.T) {
+	_, err := Between.Compare(1, 1)
+	assert.EqualError(t, err, "the comparison between needs a range")
+	_, err = ApproximatelyEqual.Compare(1, 1)
+	assert.EqualError(t, err, "the comparison approximately_equal needs a tolerance")
+}
+
+func TestComparison_ValidateBounds(t *testing.T) {
+	assert.NoError(t, Equal.ValidateBounds(nil))
+	assert.NoError(t, Between.ValidateBounds(NewRange(orderings.NewEndpoint(1, false), orderings.NewEndpoint(1, false))))
+	assert.NoError(t, ApproximatelyEqual.ValidateBounds(NewTolerance(0)))
+
+	assert.EqualError(t, Between.ValidateBounds(NewTolerance(1)), "the comparison between needs a range")
+	assert.EqualError(t, Between.ValidateBounds(NewRange(orderings.NewEndpoint(1, true), orderings.NewEndpoint(1, false))),
+		"the range ]1;1] of the comparison between is invalid: the bucket is empty")
+	assert.EqualError(t, ApproximatelyEqual.ValidateBounds(NewTolerance(-1)),
+		"the tolerance -1 of the comparison approximately_equal is negative")
+	assert.EqualError(t, Comparison("within").ValidateBounds(NewTolerance(1)), "unknown requirements.Comparison 'within'")
+}
@@ -33,11 +33,12 @@ import (
 // i.e. we want to be placed in a given data center or we do not want to be placed on a specific rack, etc.
 //
 // An example initialization could be:
-//	requirement := NewLabelRequirement(
+//	requirement, err := NewBoundedLabelRequirement(
 //		labels.NewLabel("host", "*"),
 //		labels.NewLabel("volume-types", "zfs"),
 //		Equal,
 //		1,
+//		nil,
 //	)
 // which applies to any group that is a host and requires that there is exactly 1 occurrence of the
 // label volume-types.zfs on the group.
@@ -46,9 +47,13 @@ type LabelRequirement struct {
 	Label       *labels.Label
 	Comparison  Comparison
 	Occurrences int
+	// Bounds are the bounds of the comparisons Between and ApproximatelyEqual and are nil for other comparisons.
+	Bounds *Bounds
 }
 
-// NewLabelRequirement creates a new label requirement.
+// NewLabelRequirement creates a new label requirement without validating the comparison.
+//
+// Deprecated: use NewBoundedLabelRequirement which refuses unknown comparisons.
 func NewLabelRequirement(scope, label *labels.Label, comparison Comparison, occurrences int) *LabelRequirement {
 	return &LabelRequirement{
 		Scope:       scope,
@@ -58,12 +63,33 @@ func NewLabelRequirement(scope, label *labels.Label, comparison Comparison, occu
 	}
 }
 
+// NewBoundedLabelRequirement creates a new label requirement with the bounds needed by the comparisons Between and
+// ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not fit the comparison.
+func NewBoundedLabelRequirement(scope, label *labels.Label, comparison Comparison, occurrences int,
+	bounds *Bounds) (*LabelRequirement, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &LabelRequirement{
+		Scope:       scope,
+		Label:       label,
+		Comparison:  comparison,
+		Occurrences: occurrences,
+		Bounds:      bounds,
+	}, nil
+}
+
 // Passed checks if the requirement is fulfilled by the given group within the scope groups.
 func (requirement *LabelRequirement) Passed(group *placement.Group, scopeSet *placement.ScopeSet,
 	entity *placement.Entity, transcript *placement.Transcript) bool {
 	occurrences := scopeSet.LabelScope(group, requirement.Scope).Count(requirement.Label)
-	fulfilled, err := requirement.Comparison.Compare(float64(occurrences), float64(requirement.Occurrences))
-	if err != nil || !fulfilled {
+	fulfilled, err := requirement.Comparison.CompareBounded(float64(occurrences), float64(requirement.Occurrences),
+		requirement.Bounds)
+	if err != nil {
+		transcript.IncErrored(err)
+		return false
+	}
+	if !fulfilled {
 		transcript.IncFailed()
 		return false
 	}
@@ -71,9 +97,16 @@ func (requirement *LabelRequirement) Passed(group *placement.Group, scopeSet *pl
 	return true
 }
 
+// Validate returns an error if the requirement can not be evaluated, i.e. if its comparison is unknown or its bounds
+// are invalid for its comparison.
+func (requirement *LabelRequirement) Validate() error {
+	return requirement.Comparison.ValidateBounds(requirement.Bounds)
+}
+
 func (requirement *LabelRequirement) String() string {
-	return fmt.Sprintf("requires that the occurrences of the label %v should be %v %v in scope %v",
-		requirement.Label, requirement.Comparison, requirement.Occurrences, requirement.Scope)
+	return fmt.Sprintf("requires that the occurrences of the label %v should be %v in scope %v",
+		requirement.Label, describe(requirement.Comparison, requirement.Occurrences, requirement.Bounds),
+		requirement.Scope)
 }
 
 // Composite returns false as the requirement is not composite and the name of the requirement type.
@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/svenskmand/mimir-lib/model/labels"
+	"github.com/svenskmand/mimir-lib/model/orderings"
 	"github.com/svenskmand/mimir-lib/model/placement"
 )
 
@@ -181,3 +182,25 @@ func TestLabelRequirement_Fulfilled_IsUnfulfilledForInvalidComparison(t *testing
 	)
 	assert.False(t, requirement.Passed(group, scopeSet, nil, nil))
 }
+
+func TestLabelRequirement_Passed_Between(t *testing.T) {
+	group := placement.NewGroup("group")
+	group.Labels.Add(labels.NewLabel("volume-types", "zfs"), labels.NewLabel("volume-types", "local"))
+	scopeSet := placement.NewScopeSet(nil)
+
+	requirement, err := NewBoundedLabelRequirement(nil, labels.NewLabel("volume-types", "*"), Between, 0,
+		NewRange(orderings.NewEndpoint(1, false), orderings.NewEndpoint(2, true)))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "requires that the occurrences of the label volume-types.* should be between [1;2[ in scope <nil>",
+		requirement.String())
+	transcript := placement.NewTranscript("transcript")
+	assert.False(t, requirement.Passed(group, scopeSet, nil, transcript))
+	requirement.Bounds = NewRange(orderings.NewEndpoint(1, false), orderings.NewEndpoint(2, false))
+	assert.True(t, requirement.Passed(group, scopeSet, nil, transcript))
+	requirement.Bounds = nil
+	assert.False(t, requirement.Passed(group, scopeSet, nil, transcript))
+	assert.Equal(t, 1, transcript.GroupsPassed)
+	assert.Equal(t, 1, transcript.GroupsFailed)
+	assert.Equal(t, 1, transcript.GroupsErrored)
+}
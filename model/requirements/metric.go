@@ -33,19 +33,24 @@ import (
 // certain requirements for a specific metric.
 //
 // An example initialization could be:
-//	requirement := NewMetricRequirement(
+//	requirement, err := NewBoundedMetricRequirement(
 //		metrics.DiskFree,
 //		GreaterThanEqual,
 //		256*metrics.GiB,
+//		nil,
 //	)
 // which requires that the group should have 256 GiB or more of the metric disk free.
 type MetricRequirement struct {
 	MetricType metrics.Type
 	Comparison Comparison
 	Value      float64
+	// Bounds are the bounds of the comparisons Between and ApproximatelyEqual and are nil for other comparisons.
+	Bounds *Bounds
 }
 
-// NewMetricRequirement creates a new metric requirement.
+// NewMetricRequirement creates a new metric requirement without validating the comparison.
+//
+// Deprecated: use NewBoundedMetricRequirement which refuses unknown comparisons.
 func NewMetricRequirement(metricType metrics.Type, comparison Comparison, value float64) *MetricRequirement {
 	return &MetricRequirement{
 		MetricType: metricType,
@@ -54,12 +59,31 @@ func NewMetricRequirement(metricType metrics.Type, comparison Comparison, value
 	}
 }
 
+// NewBoundedMetricRequirement creates a new metric requirement with the bounds needed by the comparisons Between and
+// ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not fit the comparison.
+func NewBoundedMetricRequirement(metricType metrics.Type, comparison Comparison, value float64,
+	bounds *Bounds) (*MetricRequirement, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &MetricRequirement{
+		MetricType: metricType,
+		Comparison: comparison,
+		Value:      value,
+		Bounds:     bounds,
+	}, nil
+}
+
 // Passed checks if the requirement is fulfilled by the given group within the scope groups.
 func (requirement *MetricRequirement) Passed(group *placement.Group, scopeSet *placement.ScopeSet,
 	entity *placement.Entity, transcript *placement.Transcript) bool {
 	value := group.Metrics.Get(requirement.MetricType)
-	fulfilled, err := requirement.Comparison.Compare(value, requirement.Value)
-	if err != nil || !fulfilled {
+	fulfilled, err := requirement.Comparison.CompareBounded(value, requirement.Value, requirement.Bounds)
+	if err != nil {
+		transcript.IncErrored(err)
+		return false
+	}
+	if !fulfilled {
 		transcript.IncFailed()
 		return false
 	}
@@ -67,9 +91,15 @@ func (requirement *MetricRequirement) Passed(group *placement.Group, scopeSet *p
 	return true
 }
 
+// Validate returns an error if the requirement can not be evaluated, i.e. if its comparison is unknown or its bounds
+// are invalid for its comparison.
+func (requirement *MetricRequirement) Validate() error {
+	return requirement.Comparison.ValidateBounds(requirement.Bounds)
+}
+
 func (requirement *MetricRequirement) String() string {
-	return fmt.Sprintf("requires that %v should be %v %v %v", requirement.MetricType.Name,
-		requirement.Comparison, requirement.Value, requirement.MetricType.Unit)
+	return fmt.Sprintf("requires that %v should be %v %v", requirement.MetricType.Name,
+		describe(requirement.Comparison, requirement.Value, requirement.Bounds), requirement.MetricType.Unit)
 }
 
 // Composite returns false as the requirement is not composite and the name of the requirement type.
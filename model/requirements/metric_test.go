@@ -79,3 +79,18 @@ func TestMetricRequirement_Fulfilled_IsUnfulfilledForInvalidComparison(t *testin
 
 	assert.False(t, requirement.Passed(group, nil, nil, nil))
 }
+
+func TestMetricRequirement_Passed_ApproximatelyEqual(t *testing.T) {
+	group := placement.NewGroup("group")
+	group.Metrics = hostWithDiskResources()
+
+	requirement, err := NewBoundedMetricRequirement(metrics.DiskFree, ApproximatelyEqual, 480*metrics.GiB,
+		NewTolerance(4*metrics.GiB))
+	assert.NoError(t, err)
+
+	assert.Equal(t, fmt.Sprintf("requires that disk_free should be approximately_equal %v within %v bytes",
+		480*metrics.GiB, 4*metrics.GiB), requirement.String())
+	assert.True(t, requirement.Passed(group, nil, nil, nil))
+	requirement.Bounds = NewTolerance(1 * metrics.GiB)
+	assert.False(t, requirement.Passed(group, nil, nil, nil))
+}
@@ -66,6 +66,11 @@ func (requirement *OrRequirement) Passed(group *placement.Group, scopeSet *place
 	return result
 }
 
+// Validate returns the first error of the sub-requirements that can not be evaluated.
+func (requirement *OrRequirement) Validate() error {
+	return validate(requirement.Requirements)
+}
+
 func (requirement *OrRequirement) String() string {
 	subRequirements := make([]string, 0, len(requirement.Requirements))
 	for _, subRequirement := range requirement.Requirements {
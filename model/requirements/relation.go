@@ -30,14 +30,16 @@ import (
 )
 
 // RelationRequirement represents a requirement on the number of occurrences for a specific relation on a group.
-// The requirement will only apply if the group contains the applies to label.
+// The requirement will only apply if the group contains the applies to label. The occurrences of a relation is the sum
+// of the weights of the relation, so a relation added with a weight of 0.5 only counts as half an occurrence.
 //
 // An example initialization could be:
-//	requirement := NewRelationRequirement(
+//	requirement, err := NewBoundedRelationRequirement(
 //		labels.NewLabel("rack", "dc1-a009"),
 //		labels.NewLabel("redis", "instance", "store1"),
 //		LessThanEqual,
 //		0,
+//		nil,
 //	)
 // which only applies to groups in rack dc1-a009 and requires that there are 0 or less occurrences of the
 // relation redis.instance.store1 on the group.
@@ -45,11 +47,16 @@ type RelationRequirement struct {
 	Scope       *labels.Label
 	Relation    *labels.Label
 	Comparison  Comparison
-	Occurrences int
+	Occurrences float64
+	// Bounds are the bounds of the comparisons Between and ApproximatelyEqual and are nil for other comparisons.
+	Bounds *Bounds
 }
 
-// NewRelationRequirement creates a new relation requirement.
-func NewRelationRequirement(scope, relation *labels.Label, comparison Comparison, occurrences int) *RelationRequirement {
+// NewRelationRequirement creates a new relation requirement without validating the comparison.
+//
+// Deprecated: use NewBoundedRelationRequirement which refuses unknown comparisons.
+func NewRelationRequirement(scope, relation *labels.Label, comparison Comparison,
+	occurrences float64) *RelationRequirement {
 	return &RelationRequirement{
 		Scope:       scope,
 		Relation:    relation,
@@ -58,12 +65,33 @@ func NewRelationRequirement(scope, relation *labels.Label, comparison Comparison
 	}
 }
 
+// NewBoundedRelationRequirement creates a new relation requirement with the bounds needed by the comparisons Between
+// and ApproximatelyEqual, it returns an error if the comparison is unknown or the bounds do not fit the comparison.
+func NewBoundedRelationRequirement(scope, relation *labels.Label, comparison Comparison, occurrences float64,
+	bounds *Bounds) (*RelationRequirement, error) {
+	if err := comparison.ValidateBounds(bounds); err != nil {
+		return nil, err
+	}
+	return &RelationRequirement{
+		Scope:       scope,
+		Relation:    relation,
+		Comparison:  comparison,
+		Occurrences: occurrences,
+		Bounds:      bounds,
+	}, nil
+}
+
 // Passed checks if the requirement is fulfilled by the given group within the scope groups.
 func (requirement *RelationRequirement) Passed(group *placement.Group, scopeSet *placement.ScopeSet,
 	entity *placement.Entity, transcript *placement.Transcript) bool {
-	occurrences := scopeSet.RelationScope(group, requirement.Scope).Count(requirement.Relation)
-	fulfilled, err := requirement.Comparison.Compare(float64(occurrences), float64(requirement.Occurrences))
-	if err != nil || !fulfilled {
+	occurrences := scopeSet.RelationScope(group, requirement.Scope).Weight(requirement.Relation)
+	fulfilled, err := requirement.Comparison.CompareBounded(occurrences, requirement.Occurrences,
+		requirement.Bounds)
+	if err != nil {
+		transcript.IncErrored(err)
+		return false
+	}
+	if !fulfilled {
 		transcript.IncFailed()
 		return false
 	}
@@ -71,9 +99,16 @@ func (requirement *RelationRequirement) Passed(group *placement.Group, scopeSet
 	return true
 }
 
+// Validate returns an error if the requirement can not be evaluated, i.e. if its comparison is unknown or its bounds
+// are invalid for its comparison.
+func (requirement *RelationRequirement) Validate() error {
+	return requirement.Comparison.ValidateBounds(requirement.Bounds)
+}
+
 func (requirement *RelationRequirement) String() string {
-	return fmt.Sprintf("requires that the occurrences of the relation %v should be %v %v in scope %v",
-		requirement.Relation, requirement.Comparison, requirement.Occurrences, requirement.Scope)
+	return fmt.Sprintf("requires that the occurrences of the relation %v should be %v in scope %v",
+		requirement.Relation, describe(requirement.Comparison, requirement.Occurrences, requirement.Bounds),
+		requirement.Scope)
 }
 
 // Composite returns false as the requirement is not composite and the name of the requirement type.
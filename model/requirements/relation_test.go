@@ -132,3 +132,45 @@ func TestRelationRequirement_Fulfilled_IsUnfulfilledForInvalidComparison(t *test
 	)
 	assert.False(t, requirement.Passed(group, scopeSet, nil, nil))
 }
+
+func TestRelationRequirement_Fulfilled_SumsTheWeightsOfTheRelation(t *testing.T) {
+	relation := labels.NewLabel("redis", "instance", "store1")
+	group := placement.NewGroup("group")
+	group.Relations.AddWeighted(relation, 0.5)
+	scopeSet := placement.NewScopeSet(nil)
+
+	requirement := NewRelationRequirement(nil, relation, LessThanEqual, 1)
+	transcript := placement.NewTranscript("transcript")
+	assert.True(t, requirement.Passed(group, scopeSet, nil, transcript))
+
+	group.Relations.AddWeighted(relation, 0.5)
+	assert.True(t, requirement.Passed(group, scopeSet, nil, transcript))
+
+	group.Relations.AddWeighted(relation, 0.5)
+	assert.False(t, requirement.Passed(group, scopeSet, nil, transcript))
+	assert.Equal(t, 2, transcript.GroupsPassed)
+	assert.Equal(t, 1, transcript.GroupsFailed)
+}
+
+func TestRelationRequirement_Fulfilled_ComparesTheWeightsWithFractionalOccurrences(t *testing.T) {
+	relation := labels.NewLabel("redis", "instance", "store1")
+	group := placement.NewGroup("group")
+	group.Relations.AddWeighted(relation, 0.5)
+	scopeSet := placement.NewScopeSet(nil)
+
+	requirement := NewRelationRequirement(nil, relation, Equal, 0.5)
+	assert.True(t, requirement.Passed(group, scopeSet, nil, nil))
+
+	group.Relations.AddWeighted(relation, 0.5)
+	assert.False(t, requirement.Passed(group, scopeSet, nil, nil))
+}
+
+func TestRelationRequirement_Passed_NotEqual(t *testing.T) {
+	relation := labels.NewLabel("redis", "instance", "store1")
+	group := placement.NewGroup("group")
+	group.Relations.Add(relation)
+	scopeSet := placement.NewScopeSet(nil)
+
+	assert.True(t, NewRelationRequirement(nil, relation, NotEqual, 0).Passed(group, scopeSet, nil, nil))
+	assert.False(t, NewRelationRequirement(nil, relation, NotEqual, 1).Passed(group, scopeSet, nil, nil))
+}